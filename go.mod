@@ -4,12 +4,18 @@ go 1.17
 
 require (
 	github.com/google/go-github/v39 v39.1.0
+	github.com/jedib0t/go-pretty/v6 v6.2.4
+	github.com/klauspost/compress v1.15.15
+	github.com/spf13/cobra v1.2.1
+	github.com/ulikunitz/xz v0.5.11
+	golang.org/x/crypto v0.0.0-20210817164053-32db794688a5
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
 	github.com/google/go-querystring v1.1.0 // indirect
 	github.com/inconshreveable/mousetrap v1.0.0 // indirect
-	github.com/jedib0t/go-pretty/v6 v6.2.4
 	github.com/mattn/go-runewidth v0.0.9 // indirect
-	github.com/spf13/cobra v1.2.1
 	github.com/spf13/pflag v1.0.5 // indirect
-	golang.org/x/crypto v0.0.0-20210817164053-32db794688a5 // indirect
 	golang.org/x/sys v0.0.0-20210615035016-665e8c7367d1 // indirect
 )
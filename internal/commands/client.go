@@ -0,0 +1,174 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v39/github"
+	"github.com/iskorotkov/package-manager-cli/internal/keys"
+	"github.com/iskorotkov/package-manager-cli/pkg/auth"
+)
+
+const (
+	gitHubHost = "github.com"
+	// anonymousRateLimit is a number of requests per hour available without authentication.
+	anonymousRateLimit = 60
+)
+
+// tokenTransport adds token to requests sent to GitHub API host.
+// Other hosts (e. g. storage that assets are redirected to) don't receive the token.
+type tokenTransport struct {
+	token string
+	host  string
+	base  http.RoundTripper
+}
+
+func (t *tokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Host != t.host {
+		return t.base.RoundTrip(req) //nolint:wrapcheck
+	}
+
+	// Request must not be modified by transport, so its copy is used.
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "token "+t.token)
+
+	return t.base.RoundTrip(req) //nolint:wrapcheck
+}
+
+// newClient returns GitHub client authenticated with the first available token. See auth.Find for details.
+// GitHub Enterprise Server is used instead of github.com if its API URL is set.
+func newClient() (*github.Client, error) {
+	token, err := findToken()
+	if err != nil {
+		return nil, err
+	}
+
+	return newClientWithToken(token)
+}
+
+func findToken() (auth.Token, error) {
+	host, err := gitHubHostname()
+	if err != nil {
+		return auth.Token{}, err
+	}
+
+	token, err := auth.Find(host, keys.TokenPath)
+	if err != nil {
+		return auth.Token{}, fmt.Errorf("error reading GitHub token: %w", err)
+	}
+
+	log.Printf("GitHub authentication for %s: %s", host, token.Source)
+
+	return token, nil
+}
+
+// gitHubHostname returns hostname of GitHub instance (e. g. "github.com" or "github.example.com").
+func gitHubHostname() (string, error) {
+	if keys.GitHubAPIURL == "" {
+		return gitHubHost, nil
+	}
+
+	u, err := url.Parse(keys.GitHubAPIURL)
+	if err != nil {
+		return "", fmt.Errorf("error parsing GitHub API URL '%s': %w", keys.GitHubAPIURL, err)
+	}
+
+	if u.Host == "" {
+		return "", fmt.Errorf("GitHub API URL '%s' must be absolute (e. g. https://github.example.com/api/v3)",
+			keys.GitHubAPIURL)
+	}
+
+	return u.Host, nil
+}
+
+// newClientWithToken returns GitHub client authenticated with token, or anonymous client if token is empty.
+func newClientWithToken(token auth.Token) (*github.Client, error) {
+	var httpClient *http.Client
+
+	var transport *tokenTransport
+
+	if token.Value != "" {
+		transport = &tokenTransport{
+			token: token.Value,
+			host:  "",
+			base:  http.DefaultTransport,
+		}
+
+		httpClient = &http.Client{Transport: transport} //nolint:exhaustivestruct
+	}
+
+	client := github.NewClient(httpClient)
+
+	if keys.GitHubAPIURL != "" {
+		var err error
+
+		client, err = newEnterpriseClient(httpClient)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if transport != nil {
+		transport.host = client.BaseURL.Host
+	}
+
+	return client, nil
+}
+
+// newEnterpriseClient returns client for GitHub Enterprise Server API.
+// Uploads aren't used, so upload URL is derived from API URL if it isn't set.
+func newEnterpriseClient(httpClient *http.Client) (*github.Client, error) {
+	uploadURL := keys.GitHubUploadURL
+	if uploadURL == "" {
+		uploadURL = strings.TrimSuffix(strings.TrimSuffix(keys.GitHubAPIURL, "/"), "/api/v3")
+	}
+
+	client, err := github.NewEnterpriseClient(keys.GitHubAPIURL, uploadURL, httpClient)
+	if err != nil {
+		return nil, fmt.Errorf("error creating GitHub Enterprise client for '%s': %w", keys.GitHubAPIURL, err)
+	}
+
+	log.Printf("using GitHub API at %s", client.BaseURL)
+
+	return client, nil
+}
+
+// explainRateLimit returns error with reset time of GitHub API rate limit if err was caused by exceeding it.
+func explainRateLimit(err error) error {
+	var rateLimitErr *github.RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		reset := rateLimitErr.Rate.Reset.Time
+
+		hint := ""
+		if rateLimitErr.Rate.Limit <= anonymousRateLimit {
+			hint = ", set GITHUB_TOKEN or PM_GITHUB_TOKEN to increase the limit"
+			if keys.GitHubAPIURL != "" {
+				hint = ", set GH_ENTERPRISE_TOKEN or PM_GITHUB_TOKEN to increase the limit"
+			}
+		}
+
+		return fmt.Errorf("GitHub API rate limit of %d requests per hour exceeded, it resets at %s (in %s)%s: %w",
+			rateLimitErr.Rate.Limit, reset.Local().Format(time.Kitchen), formatDuration(time.Until(reset)), hint, err)
+	}
+
+	var abuseErr *github.AbuseRateLimitError
+	if errors.As(err, &abuseErr) {
+		return fmt.Errorf("GitHub API secondary rate limit exceeded, retry in %s: %w",
+			formatDuration(abuseErr.GetRetryAfter()), err)
+	}
+
+	return err
+}
+
+func formatDuration(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+
+	return d.Round(time.Second).String()
+}
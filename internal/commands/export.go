@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/iskorotkov/package-manager-cli/internal/keys"
+	"github.com/iskorotkov/package-manager-cli/internal/manifest"
+	"github.com/iskorotkov/package-manager-cli/internal/metadata"
+	"github.com/spf13/cobra"
+)
+
+//nolint:gochecknoinits
+func init() {
+	exportCmd := wrapCommand(&cobra.Command{ //nolint:exhaustivestruct
+		Use:   "export",
+		Short: "export installed packages to file (or stdout)",
+		Args:  cobra.MaximumNArgs(1),
+		RunE:  export,
+	})
+
+	rootCmd.AddCommand(exportCmd)
+}
+
+func export(_ *cobra.Command, args []string) error {
+	installed, err := metadata.ReadAll(keys.MetadataPath)
+	if err != nil {
+		return err
+	}
+
+	e := manifest.Export{
+		Packages: make([]manifest.Exported, 0, len(installed)),
+	}
+
+	for _, m := range installed {
+		e.Packages = append(e.Packages, manifest.Exported{
+			Repo:         fmt.Sprintf("%s/%s", m.Package.Owner, m.Package.Repo),
+			Version:      m.Package.Version.Value,
+			Asset:        m.Asset.Name,
+			AssetPattern: m.Package.AssetPattern,
+			Pinned:       m.Package.Pinned,
+			Constraint:   m.Package.Constraint,
+			Desktop:      m.Installation.Desktop,
+			Prerelease:   m.Package.Prerelease,
+		})
+	}
+
+	log.Printf("exported packages: %+v", e.Packages)
+
+	if len(args) == 0 {
+		return manifest.WriteExport(os.Stdout, e)
+	}
+
+	file, err := os.Create(args[0])
+	if err != nil {
+		return fmt.Errorf("error creating export file: %w", err)
+	}
+
+	defer func(file io.Closer) {
+		_ = file.Close()
+	}(file)
+
+	if err := manifest.WriteExport(file, e); err != nil {
+		return err
+	}
+
+	fmt.Printf("exported %d packages to '%s'\n", len(e.Packages), args[0])
+
+	return nil
+}
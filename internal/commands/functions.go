@@ -27,6 +27,8 @@ func wrapCommandFunc(name string, f commandFunc) commandFunc {
 
 		err := f(cmd, args)
 		if err != nil {
+			err = explainRateLimit(err)
+
 			log.Printf("error when executing command: %v", err)
 		}
 
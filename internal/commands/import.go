@@ -0,0 +1,115 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/google/go-github/v39/github"
+	"github.com/iskorotkov/package-manager-cli/internal/manifest"
+	"github.com/iskorotkov/package-manager-cli/pkg/assets"
+	"github.com/iskorotkov/package-manager-cli/pkg/packages"
+	"github.com/iskorotkov/package-manager-cli/pkg/xlog"
+	"github.com/spf13/cobra"
+)
+
+//nolint:gochecknoinits
+func init() {
+	importCmd := wrapCommand(&cobra.Command{ //nolint:exhaustivestruct
+		Use:   "import",
+		Short: "install packages from export file",
+		Args:  cobra.ExactArgs(1),
+		RunE:  importPackages,
+	})
+
+	importCmd.Flags().Bool("latest", false, "install the latest releases instead of exported versions")
+	importCmd.Flags().Bool("require-signature", false, "fail if release signature can't be verified with trusted keys")
+	addAssetFlags(importCmd)
+
+	rootCmd.AddCommand(importCmd)
+}
+
+func importPackages(cmd *cobra.Command, args []string) error {
+	latest, err := cmd.Flags().GetBool("latest")
+	if err != nil {
+		return fmt.Errorf("error reading flag value: %w", err)
+	}
+
+	requireSignature, err := cmd.Flags().GetBool("require-signature")
+	if err != nil {
+		return fmt.Errorf("error reading flag value: %w", err)
+	}
+
+	assetOpts, err := readAssetOptions(cmd)
+	if err != nil {
+		return err
+	}
+
+	e, err := manifest.ReadExport(args[0])
+	if err != nil {
+		return err
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	opts := installOptions{
+		desktop:          false,
+		requireSignature: requireSignature,
+		asset:            assetOpts,
+		sha256:           "",
+	}
+
+	for _, exported := range e.Packages {
+		if err := importPackage(client, exported, latest, opts); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// importPackage installs exported version and asset of the package.
+// If latest is set, the latest release matching package constraint is installed instead, and package isn't pinned.
+func importPackage(client *github.Client, exported manifest.Exported, latest bool, opts installOptions) error {
+	xlog.Push(exported.Repo)
+	defer xlog.Pop()
+
+	// Repo is validated when export file is read.
+	parts := strings.SplitN(exported.Repo, "/", 2) //nolint:gomnd
+
+	pkg := packages.Package{ //nolint:exhaustivestruct
+		Owner:        parts[0],
+		Repo:         parts[1],
+		Constraint:   exported.Constraint,
+		AssetPattern: exported.AssetPattern,
+		Prerelease:   exported.Prerelease,
+	}
+
+	opts.desktop = exported.Desktop
+	if err := opts.asset.setPattern(exported.AssetPattern); err != nil {
+		return err
+	}
+
+	// Asset names usually contain version, so they can't be used for other releases.
+	if !latest {
+		// Tags that can't be parsed are used as is.
+		version, _ := packages.ParseVersion(exported.Version)
+		version.Value = exported.Version
+
+		pkg.Version = version
+		pkg.Pinned = exported.Pinned
+
+		if exported.Asset != "" {
+			if err := opts.asset.setPattern(assets.ExactPattern(exported.Asset)); err != nil {
+				return err
+			}
+		}
+	}
+
+	log.Printf("importing package: %+v", pkg)
+
+	return installPackage(client, pkg, true, opts)
+}
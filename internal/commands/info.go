@@ -33,7 +33,10 @@ func info(_ *cobra.Command, args []string) error {
 
 	log.Printf("package name: %s", packageName)
 
-	client := github.NewClient(nil)
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
 
 	result, _, err := client.Search.Repositories(context.Background(), packageName, nil)
 	if err != nil {
@@ -12,9 +12,11 @@ import (
 	"github.com/google/go-github/v39/github"
 	"github.com/iskorotkov/package-manager-cli/internal/keys"
 	"github.com/iskorotkov/package-manager-cli/internal/metadata"
+	"github.com/iskorotkov/package-manager-cli/pkg/appimages"
 	"github.com/iskorotkov/package-manager-cli/pkg/archives"
 	"github.com/iskorotkov/package-manager-cli/pkg/assets"
-	"github.com/iskorotkov/package-manager-cli/pkg/binaries"
+	"github.com/iskorotkov/package-manager-cli/pkg/checksums"
+	"github.com/iskorotkov/package-manager-cli/pkg/packages"
 	"github.com/iskorotkov/package-manager-cli/pkg/xlog"
 	"github.com/spf13/cobra"
 )
@@ -28,24 +30,222 @@ func init() {
 		RunE:  install,
 	})
 
+	installCmd.Flags().Bool("desktop", false, "create desktop entry and icon for AppImage packages")
+	installCmd.Flags().Bool("require-signature", false, "fail if release signature can't be verified with trusted keys")
+	installCmd.Flags().Bool("pre", false, "install prereleases and keep upgrading the package to them")
+	installCmd.Flags().String("asset", "", "select asset with glob or regular expression matching its whole name")
+	addAssetFlags(installCmd)
+
 	rootCmd.AddCommand(installCmd)
 }
 
-func install(_ *cobra.Command, args []string) error {
+// installOptions control how assets are installed.
+type installOptions struct {
+	// desktop enables desktop integration for AppImages.
+	desktop bool
+	// requireSignature fails installation if signature can't be verified.
+	requireSignature bool
+	// asset controls how release asset is selected.
+	asset assetOptions
+	// sha256 is an expected digest of the asset (e. g. from lockfile). Digest isn't checked if it's empty.
+	sha256 string
+}
+
+func install(cmd *cobra.Command, args []string) error {
 	packageName := args[0]
 
+	desktop, err := cmd.Flags().GetBool("desktop")
+	if err != nil {
+		return fmt.Errorf("error reading flag value: %w", err)
+	}
+
+	requireSignature, err := cmd.Flags().GetBool("require-signature")
+	if err != nil {
+		return fmt.Errorf("error reading flag value: %w", err)
+	}
+
+	pre, err := cmd.Flags().GetBool("pre")
+	if err != nil {
+		return fmt.Errorf("error reading flag value: %w", err)
+	}
+
+	assetPattern, err := cmd.Flags().GetString("asset")
+	if err != nil {
+		return fmt.Errorf("error reading flag value: %w", err)
+	}
+
+	assetOpts, err := readAssetOptions(cmd)
+	if err != nil {
+		return err
+	}
+
+	if err := assetOpts.setPattern(assetPattern); err != nil {
+		return err
+	}
+
 	xlog.Push(packageName)
 	defer xlog.Pop()
 
 	log.Printf("package name: %s", packageName)
 
-	client := github.NewClient(nil)
+	pkg, err := packages.ParsePackage(packageName)
+	if err != nil {
+		return fmt.Errorf("error parsing package name: %w", err)
+	}
+
+	pkg.AssetPattern = assetPattern
+	pkg.Prerelease = pre
 
-	asset, err := selectAsset(client, packageName)
+	log.Printf("parsed package: %+v", pkg)
+
+	client, err := newClient()
 	if err != nil {
 		return err
 	}
 
+	return installPackage(client, pkg, hasOwner(packageName), installOptions{
+		desktop:          desktop,
+		requireSignature: requireSignature,
+		asset:            assetOpts,
+		sha256:           "",
+	})
+}
+
+// installPackage installs the release of the package matching its version or constraint and makes it active.
+// Nothing is downloaded if the release is already installed.
+func installPackage(client *github.Client, pkg packages.Package, exactRepo bool, opts installOptions) error {
+	asset, err := selectAsset(client, pkg, exactRepo, opts.asset)
+	if err != nil {
+		return err
+	}
+
+	return installSelected(client, pkg, asset, opts)
+}
+
+// installSelected installs selected asset and makes it active.
+// Version requirements and asset pattern are taken from pkg. Asset pattern chosen by user overrides the latter.
+func installSelected(client *github.Client, pkg packages.Package, asset assets.AssetData, opts installOptions) error {
+	m, err := installedMetadata(asset)
+	if err != nil {
+		return err
+	}
+
+	tx := newTransaction()
+	defer tx.rollback()
+
+	v, installed := m.FindVersion(asset.Release.GetTagName())
+
+	// Version is installed again if asset pattern selects another asset than the installed one.
+	if installed && opts.asset.pattern != nil && v.Asset.Name != asset.Asset.GetName() {
+		log.Printf("version %s was installed from asset %s, replacing it", v.Version.Value, v.Asset.Name)
+
+		if err := tx.stash(v.Package); err != nil {
+			return err
+		}
+
+		installed = false
+	}
+
+	active := installed && m.IsActive(v)
+
+	if installed && opts.sha256 != "" && v.Asset.SHA256 == "" {
+		// Digest isn't recorded for versions installed before digests were saved, so asset is downloaded again.
+		v, err = recordDigest(tx, client, asset, v)
+		if err != nil {
+			return err
+		}
+
+		m.AddVersion(v)
+
+		if active {
+			m.Asset = v.Asset
+		}
+	}
+
+	if installed && opts.sha256 != "" {
+		if err := checksums.Verify(v.Asset.SHA256, opts.sha256); err != nil {
+			return fmt.Errorf("installed version %s of '%s' was installed from another asset: %w",
+				v.Version.Value, asset.Repository.GetFullName(), err)
+		}
+	}
+
+	switch {
+	case active:
+		fmt.Printf("package '%s' is already installed (%s)\n", asset.Repository.GetFullName(), v.Version.Value)
+	case installed:
+		log.Printf("release is already installed: %+v", v)
+	default:
+		v, err = installAsset(tx, client, asset, opts)
+		if err != nil {
+			return err
+		}
+
+		m.AddVersion(v)
+	}
+
+	m.Installation.Desktop = m.Installation.Desktop || opts.desktop
+
+	if !active {
+		if err := activateVersion(tx, &m, v, asset.Repository.GetDescription()); err != nil {
+			return err
+		}
+	}
+
+	m.Package.Pinned = pkg.Pinned
+	m.Package.Constraint = pkg.Constraint
+	m.Package.Prerelease = pkg.Prerelease
+
+	switch {
+	case asset.Pattern != "":
+		m.Package.AssetPattern = asset.Pattern
+	case pkg.AssetPattern != "":
+		m.Package.AssetPattern = pkg.AssetPattern
+	}
+
+	log.Printf("saving metadata to: %s", keys.MetadataPath)
+
+	if err := metadata.Save(keys.MetadataPath, m, keys.MetadataPermissions); err != nil {
+		return fmt.Errorf("error saving package metadata: %w", err)
+	}
+
+	tx.commit()
+
+	if !active {
+		fmt.Printf("installed package '%s' (%s)\n", asset.Repository.GetFullName(), v.Version.Value)
+	}
+
+	return nil
+}
+
+// installedMetadata returns metadata of the package if any of its versions is already installed.
+// Otherwise, it returns metadata without installed versions.
+func installedMetadata(asset assets.AssetData) (packages.Metadata, error) {
+	installed, err := metadata.ReadAll(keys.MetadataPath)
+	if err != nil {
+		return packages.Metadata{}, err
+	}
+
+	m := metadata.New(asset)
+
+	if existing, ok := findInstalled(installed, m.Package, true); ok {
+		log.Printf("package is already installed: %+v", existing.Package)
+
+		return existing, nil
+	}
+
+	return m, nil
+}
+
+// installAsset downloads asset and moves it to the version folder.
+// It returns installed version, but doesn't make it active or save metadata.
+// Package is extracted to a staging folder first and then moved to the version folder,
+// so all created files and folders can be removed by rolling back transaction.
+func installAsset(
+	tx *transaction,
+	client *github.Client,
+	asset assets.AssetData,
+	opts installOptions,
+) (packages.InstalledVersion, error) {
 	log.Printf("selected repo: %s", asset.Repository.GetFullName())
 	log.Printf("selected release: %s", asset.Release.GetTagName())
 	log.Printf("selected asset: %s", asset.Asset.GetName())
@@ -54,66 +254,226 @@ func install(_ *cobra.Command, args []string) error {
 
 	log.Printf("downloading package to: %s", downloadPath)
 
-	if err := downloadAsset(client, asset, downloadPath); err != nil {
-		return err
+	if err := downloadAsset(tx.ctx, client, asset, downloadPath); err != nil {
+		return packages.InstalledVersion{}, err
 	}
 
 	defer cleanupFile(downloadPath)
 
 	log.Printf("downloaded to: %s", downloadPath)
 
-	packagePath := filepath.Join(keys.PackagesPath, asset.Repository.GetName())
+	checksum, err := verifyChecksum(client, asset, downloadPath)
+	if err != nil {
+		return packages.InstalledVersion{}, err
+	}
 
-	log.Printf("moving package to: %s", packagePath)
+	if opts.sha256 != "" {
+		if err := checksums.Verify(checksum.digest, opts.sha256); err != nil {
+			return packages.InstalledVersion{}, fmt.Errorf("asset '%s' was changed: %w", asset.Asset.GetName(), err)
+		}
+	}
 
-	if err := moveToPackageFolder(asset, downloadPath, packagePath); err != nil {
-		return err
+	signature, err := verifySignature(client, asset, downloadPath, checksum, opts.requireSignature)
+	if err != nil {
+		return packages.InstalledVersion{}, err
 	}
 
-	log.Printf("creating symlinks at: %s", keys.SymlinksPath)
+	packagePath := versionPath(asset)
 
-	symlinks, err := binaries.AddSymlinks(packagePath, keys.SymlinksPath, keys.SymlinksPermissions)
+	format, err := stagePackage(tx, asset, downloadPath, packagePath)
 	if err != nil {
-		return fmt.Errorf("error adding package to path: %w", err)
+		return packages.InstalledVersion{}, err
 	}
 
-	log.Printf("saved symlinks: %+v", symlinks)
+	binariesPath := packagePath
 
-	log.Printf("saving metadata to: %s", keys.MetadataPath)
+	// Binaries of deb and rpm packages are stored in "usr/bin" folder.
+	if format.IsSystemPackage() {
+		binariesPath = filepath.Join(packagePath, "usr")
+	}
 
-	err = metadata.Save(packagePath, keys.MetadataPath, asset, symlinks, keys.MetadataPermissions)
+	v := metadata.NewVersion(packagePath, binariesPath, asset)
+	v.Asset.SHA256 = checksum.digest
+	v.Asset.Verified = checksum.verified
+	v.Asset.Signature = signature
+	v.AppImage = format.IsAppImage()
+
+	if err := tx.check(); err != nil {
+		return packages.InstalledVersion{}, err
+	}
+
+	return v, nil
+}
+
+// recordDigest downloads asset the installed version was installed from and returns the version with its digest.
+func recordDigest(
+	tx *transaction,
+	client *github.Client,
+	asset assets.AssetData,
+	v packages.InstalledVersion,
+) (packages.InstalledVersion, error) {
+	if v.Asset.Name != asset.Asset.GetName() {
+		return packages.InstalledVersion{}, fmt.Errorf("installed version %s of '%s' was installed from asset '%s', not '%s'",
+			v.Version.Value, asset.Repository.GetFullName(), v.Asset.Name, asset.Asset.GetName())
+	}
+
+	log.Printf("digest of installed version %s isn't recorded, downloading asset", v.Version.Value)
+
+	downloadPath := filepath.Join(keys.DownloadsPath, asset.Asset.GetName())
+
+	if err := downloadAsset(tx.ctx, client, asset, downloadPath); err != nil {
+		return packages.InstalledVersion{}, err
+	}
+
+	defer cleanupFile(downloadPath)
+
+	digest, err := checksums.File(downloadPath)
 	if err != nil {
-		return fmt.Errorf("error saving package metadata: %w", err)
+		return packages.InstalledVersion{}, fmt.Errorf("error calculating digest of asset: %w", err)
 	}
 
-	fmt.Printf("installed package '%s'", asset.Repository.GetFullName())
+	v.Asset.SHA256 = digest
 
-	return nil
+	return v, nil
 }
 
-func moveToPackageFolder(asset assets.AssetData, downloadPath string, packagePath string) error {
-	if strings.HasSuffix(asset.Asset.GetName(), ".tar.gz") {
-		xlog.Push("archive")
+// versionPath returns folder for the release of the package, e. g. "<packages>/derailed/k9s/v0.25.0".
+func versionPath(asset assets.AssetData) string {
+	// Tags may contain slashes (e. g. "kustomize/v4.4.0"), but version must be stored in a single folder.
+	version := strings.ReplaceAll(asset.Release.GetTagName(), "/", "-")
+
+	return filepath.Join(keys.PackagesPath, asset.Repository.GetOwner().GetLogin(), asset.Repository.GetName(), version)
+}
+
+// stagePackage extracts asset to a staging folder and then moves it to the package folder.
+func stagePackage(
+	tx *transaction,
+	asset assets.AssetData,
+	downloadPath string,
+	packagePath string,
+) (archives.Format, error) {
+	if err := tx.check(); err != nil {
+		return archives.Format{}, err
+	}
+
+	if _, err := os.Stat(packagePath); err == nil {
+		return archives.Format{}, fmt.Errorf("package folder '%s' already exists", packagePath)
+	}
+
+	if err := os.MkdirAll(keys.PackagesPath, keys.PackagesPermissions); err != nil {
+		return archives.Format{}, fmt.Errorf("error creating packages folder: %w", err)
+	}
+
+	stagingPath, err := os.MkdirTemp(keys.PackagesPath, ".staging-"+asset.Repository.GetName()+"-")
+	if err != nil {
+		return archives.Format{}, fmt.Errorf("error creating staging folder: %w", err)
+	}
+
+	// Staging folder doesn't exist anymore if it was moved to the package folder.
+	defer func(path string) {
+		_ = os.RemoveAll(path)
+	}(stagingPath)
+
+	log.Printf("moving package to staging folder: %s", stagingPath)
+
+	format, err := moveToPackageFolder(asset, downloadPath, stagingPath)
+	if err != nil {
+		return archives.Format{}, err
+	}
+
+	if err := tx.check(); err != nil {
+		return archives.Format{}, err
+	}
+
+	log.Printf("moving package to: %s", packagePath)
+
+	if err := os.MkdirAll(filepath.Dir(packagePath), keys.PackagesPermissions); err != nil {
+		return archives.Format{}, fmt.Errorf("error creating package folder: %w", err)
+	}
+
+	if err := os.Rename(stagingPath, packagePath); err != nil {
+		return archives.Format{}, fmt.Errorf("error moving package from staging folder: %w", err)
+	}
+
+	tx.created(packagePath)
+
+	return format, nil
+}
+
+// integrateAppImage creates desktop entry and icon for AppImage.
+// Desktop integration is optional, so errors are reported, but don't fail installation.
+func integrateAppImage(appImage string, description string) []string {
+	name := filepath.Base(appImage)
+
+	log.Printf("creating desktop entry at: %s", keys.DataPath)
+
+	files, err := appimages.Integrate(
+		appImage,
+		name,
+		description,
+		keys.DataPath,
+		keys.DataPermissions,
+	)
+	if err != nil {
+		log.Printf("error creating desktop entry: %v", err)
+		fmt.Printf("error creating desktop entry: %v\n", err)
+	}
+
+	log.Printf("created desktop files: %+v", files)
+
+	return files
+}
+
+func moveToPackageFolder(asset assets.AssetData, downloadPath string, packagePath string) (archives.Format, error) {
+	format, err := archives.Detect(downloadPath)
+	if err != nil {
+		return archives.Format{}, fmt.Errorf("error detecting format of asset '%s': %w", asset.Asset.GetName(), err)
+	}
+
+	if format.IsAppImage() {
+		xlog.Push("appimage")
 		defer xlog.Pop()
 
-		log.Printf("extracting .tar.gz archive")
+		log.Printf("moving AppImage to package folder")
+
+		name := appimages.BinaryName(asset.Repository.GetName())
+
+		if err := moveFileToPackageFolder(downloadPath, packagePath, name, keys.PackagesPermissions); err != nil {
+			return archives.Format{}, err
+		}
 
-		if err := archives.ExtractTarGz(downloadPath, packagePath, keys.PackagesPermissions); err != nil {
-			return fmt.Errorf("error extracting tar.gz file: %w", err)
+		if err := os.Chmod(filepath.Join(packagePath, name), keys.PackagesPermissions); err != nil {
+			return archives.Format{}, fmt.Errorf("error making AppImage executable: %w", err)
 		}
-	} else {
-		xlog.Push("binary")
+
+		return format, nil
+	}
+
+	if format.IsArchive() || format.IsCompressed() {
+		xlog.Push("archive")
 		defer xlog.Pop()
 
-		log.Printf("moving binary file to package folder")
+		log.Printf("extracting file with format: %+v", format)
 
-		err := moveFileToPackageFolder(downloadPath, packagePath, keys.PackagesPermissions, asset.Repository)
+		err = archives.Extract(downloadPath, packagePath, asset.Repository.GetName(), format, keys.PackagesPermissions)
 		if err != nil {
-			return err
+			return archives.Format{}, fmt.Errorf("error extracting file: %w", err)
 		}
+
+		return format, nil
 	}
 
-	return nil
+	xlog.Push("binary")
+	defer xlog.Pop()
+
+	log.Printf("moving binary file to package folder")
+
+	err = moveFileToPackageFolder(downloadPath, packagePath, asset.Repository.GetName(), keys.PackagesPermissions)
+	if err != nil {
+		return archives.Format{}, err
+	}
+
+	return format, nil
 }
 
 // cleanupFile removes file if it still exists.
@@ -125,76 +485,131 @@ func cleanupFile(file string) {
 	}
 }
 
-func moveFileToPackageFolder(src string, dest string, permissions os.FileMode, repo *github.Repository) error {
+func moveFileToPackageFolder(src string, dest string, name string, permissions os.FileMode) error {
 	if err := os.MkdirAll(dest, permissions); err != nil {
 		return fmt.Errorf("error creating package folder: %w", err)
 	}
 
-	if err := os.Rename(src, filepath.Join(dest, repo.GetName())); err != nil {
+	if err := os.Rename(src, filepath.Join(dest, name)); err != nil {
 		return fmt.Errorf("error moving file to package folder: %w", err)
 	}
 
 	return nil
 }
 
-func selectAsset(client *github.Client, packageName string) (assets.AssetData, error) {
-	result, _, err := client.Search.Repositories(context.Background(), packageName, nil)
+// selectAsset returns asset of the release matching package version or constraint.
+// Asset is selected using pattern if it's not empty, and using platform detection otherwise.
+func selectAsset(
+	client *github.Client,
+	pkg packages.Package,
+	exactRepo bool,
+	opts assetOptions,
+) (assets.AssetData, error) {
+	repo, err := findRepository(client, pkg, exactRepo)
 	if err != nil {
-		return assets.AssetData{}, fmt.Errorf("error searching repositories: %w", err)
+		return assets.AssetData{}, err
 	}
 
-	if len(result.Repositories) == 0 {
-		return assets.AssetData{}, fmt.Errorf("no results")
-	}
-
-	repo := result.Repositories[0]
-
-	releases, _, err := client.Repositories.ListReleases(
-		context.Background(),
+	release, err := findRelease(
+		client,
 		repo.GetOwner().GetLogin(),
 		repo.GetName(),
-		nil,
+		pkg.Version,
+		pkg.Constraint,
+		pkg.Prerelease,
 	)
 	if err != nil {
-		return assets.AssetData{}, fmt.Errorf("error getting releases: %w", err)
+		return assets.AssetData{}, err
 	}
 
-	if len(releases) == 0 {
-		return assets.AssetData{}, fmt.Errorf("no releases available")
-	}
-
-	release := releases[0]
-
-	asset, err := assets.ForPlatform(release.Assets, getPlatforms())
+	asset, pattern, err := findAsset(release, opts)
 	if err != nil {
-		return assets.AssetData{}, fmt.Errorf("no assets available: %w", err)
+		return assets.AssetData{}, err
 	}
 
 	return assets.AssetData{
 		Repository: repo,
 		Release:    release,
 		Asset:      asset,
+		Pattern:    pattern,
 	}, nil
 }
 
-func downloadAsset(client *github.Client, asset assets.AssetData, dest string) error {
+// findAsset returns release asset matching pattern if it's not empty, or asset for platforms otherwise.
+// If asset for platforms is ambiguous or missing, user is asked to choose it, and the pattern of the chosen asset
+// is returned as well.
+func findAsset(release *github.RepositoryRelease, opts assetOptions) (*github.ReleaseAsset, string, error) {
+	ranked := assets.Rank(release.Assets, opts.platforms, opts.libc)
+
+	if opts.explain {
+		fmt.Printf("scores of assets in release %s:\n", release.GetTagName())
+		printScores(ranked)
+	}
+
+	if opts.pattern == nil {
+		if opts.interactive && assets.IsAmbiguous(ranked) && isTerminal() {
+			asset, err := pickAsset(release, ranked)
+			if err != nil {
+				return nil, "", err
+			}
+
+			return asset, assets.NamePattern(asset.GetName(), release.GetTagName()), nil
+		}
+
+		asset, err := assets.ForPlatform(release.Assets, opts.platforms, opts.libc)
+		if err != nil {
+			return nil, "", fmt.Errorf("no assets available: %w", err)
+		}
+
+		return asset, "", nil
+	}
+
+	asset, err := assets.ForPattern(release.Assets, *opts.pattern, opts.platforms, opts.libc)
+	if err != nil {
+		return nil, "", fmt.Errorf("no assets available: %w", err)
+	}
+
+	return asset, "", nil
+}
+
+// findRepository returns the repo with exactly the same owner and name when exactRepo is set,
+// otherwise it returns the first search result for the repo name.
+func findRepository(client *github.Client, pkg packages.Package, exactRepo bool) (*github.Repository, error) {
+	if exactRepo {
+		repo, _, err := client.Repositories.Get(context.Background(), pkg.Owner, pkg.Repo)
+		if err != nil {
+			return nil, fmt.Errorf("error getting repository '%s/%s': %w", pkg.Owner, pkg.Repo, err)
+		}
+
+		return repo, nil
+	}
+
+	result, _, err := client.Search.Repositories(context.Background(), pkg.Repo, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error searching repositories: %w", err)
+	}
+
+	if len(result.Repositories) == 0 {
+		return nil, fmt.Errorf("no results")
+	}
+
+	return result.Repositories[0], nil
+}
+
+// hasOwner reports whether package name contains an explicit owner, e. g. "derailed/k9s".
+func hasOwner(packageName string) bool {
+	return strings.Contains(packageName, "/")
+}
+
+func downloadAsset(ctx context.Context, client *github.Client, asset assets.AssetData, dest string) error {
 	if err := os.MkdirAll(filepath.Dir(dest), keys.DownloadsPermissions); err != nil && !errors.Is(err, os.ErrExist) {
 		return fmt.Errorf("error creating folder for downloads: %w", err)
 	}
 
 	downloader := assets.NewDownloader(client)
-	if err := downloader.Download(context.Background(), asset.Repository, asset.Asset, dest); err != nil {
+	if err := downloader.Download(ctx, asset.Repository, asset.Asset, dest); err != nil {
 		return fmt.Errorf("error downloading file: %w", err)
 	}
 
 	return nil
 }
-
-func getPlatforms() []assets.Platform {
-	return []assets.Platform{
-		{OS: assets.OSLinux, Arch: assets.ArchX64},
-		{OS: assets.OSLinux, Arch: assets.ArchX86},
-		{OS: assets.OSLinux, Arch: assets.ArchUnknown},
-		{OS: assets.OSUnknown, Arch: assets.ArchUnknown},
-	}
-}
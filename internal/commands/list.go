@@ -1,16 +1,14 @@
 package commands
 
 import (
-	"errors"
 	"fmt"
-	"io/ioutil"
 	"log"
-	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/iskorotkov/package-manager-cli/internal/keys"
 	"github.com/iskorotkov/package-manager-cli/internal/metadata"
+	"github.com/iskorotkov/package-manager-cli/pkg/packages"
 	"github.com/iskorotkov/package-manager-cli/pkg/xlog"
 	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/spf13/cobra"
@@ -29,57 +27,36 @@ func init() {
 }
 
 func list(_ *cobra.Command, _ []string) error {
-	dir, err := ioutil.ReadDir(keys.MetadataPath)
-	if errors.Is(err, os.ErrNotExist) {
-		printNoPackagesInstalled()
-
-		return nil
-	} else if err != nil {
-		return fmt.Errorf("error opening metadata folder: %w", err)
+	installed, err := metadata.ReadAll(keys.MetadataPath)
+	if err != nil {
+		return err
 	}
 
-	if len(dir) == 0 {
+	if len(installed) == 0 {
 		printNoPackagesInstalled()
 
 		return nil
 	}
 
 	t := createTable()
-	t.AppendHeader(table.Row{"repo", "version", "binaries"})
-
-	packages := make([]string, 0, len(dir))
+	t.AppendHeader(table.Row{"repo", "version", "other versions", "binaries"})
 
-	for _, file := range dir {
-		packages = append(packages, file.Name())
+	for _, m := range installed {
+		addPackageRow(t, m)
 	}
 
-	log.Printf("installed packages: %+v", packages)
-
-	for _, name := range packages {
-		path := filepath.Join(keys.MetadataPath, name)
-
-		if err := addPackageRow(t, path, name); err != nil {
-			return err
-		}
-	}
-
-	fmt.Printf("%d packages installed\n", len(dir))
+	fmt.Printf("%d packages installed\n", len(installed))
 
 	t.Render()
 
 	return nil
 }
 
-func addPackageRow(t table.Writer, path string, name string) error {
-	xlog.Push(name)
-	defer xlog.Pop()
+func addPackageRow(t table.Writer, m packages.Metadata) {
+	fullName := fmt.Sprintf("%s/%s", m.Package.Owner, m.Package.Repo)
 
-	log.Printf("package metadata at path: %s", path)
-
-	m, err := metadata.Read(path)
-	if err != nil {
-		return fmt.Errorf("error reading package metadata: %w", err)
-	}
+	xlog.Push(fullName)
+	defer xlog.Pop()
 
 	binaries := make([]string, 0, len(m.Installation.Symlinks))
 
@@ -89,13 +66,20 @@ func addPackageRow(t table.Writer, path string, name string) error {
 
 	log.Printf("package binaries: %+v", binaries)
 
+	var otherVersions []string
+
+	for _, v := range m.Installation.Versions {
+		if !m.IsActive(v) {
+			otherVersions = append(otherVersions, v.Version.Value)
+		}
+	}
+
 	t.AppendRow(table.Row{
-		fmt.Sprintf("%s/%s", m.Package.Owner, m.Package.Repo),
+		fullName,
 		m.Package.Version.Value,
+		strings.Join(otherVersions, ", "),
 		strings.Join(binaries, ", "),
 	})
-
-	return nil
 }
 
 func printNoPackagesInstalled() {
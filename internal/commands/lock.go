@@ -0,0 +1,163 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path/filepath"
+
+	"github.com/google/go-github/v39/github"
+	"github.com/iskorotkov/package-manager-cli/internal/keys"
+	"github.com/iskorotkov/package-manager-cli/internal/manifest"
+	"github.com/iskorotkov/package-manager-cli/pkg/assets"
+	"github.com/iskorotkov/package-manager-cli/pkg/packages"
+	"github.com/iskorotkov/package-manager-cli/pkg/xlog"
+	"github.com/spf13/cobra"
+)
+
+//nolint:gochecknoinits
+func init() {
+	lockCmd := wrapCommand(&cobra.Command{ //nolint:exhaustivestruct
+		Use:   "lock",
+		Short: "write lockfile with exact releases and assets of packages listed in manifest file",
+		Args:  cobra.NoArgs,
+		RunE:  lock,
+	})
+
+	lockCmd.Flags().StringP("file", "f", manifest.DefaultPath, "path to manifest file")
+	addAssetFlags(lockCmd)
+
+	rootCmd.AddCommand(lockCmd)
+}
+
+func lock(cmd *cobra.Command, _ []string) error {
+	file, err := cmd.Flags().GetString("file")
+	if err != nil {
+		return fmt.Errorf("error reading flag value: %w", err)
+	}
+
+	assetOpts, err := readAssetOptions(cmd)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("reading manifest: %s", file)
+
+	mf, err := manifest.Read(file)
+	if err != nil {
+		return err
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	lockfile := manifest.Lockfile{
+		Packages: make([]manifest.Locked, 0, len(mf.Packages)),
+	}
+
+	for _, p := range mf.Packages {
+		locked, err := lockPackage(client, p, assetOpts)
+		if err != nil {
+			return err
+		}
+
+		lockfile.Packages = append(lockfile.Packages, locked)
+	}
+
+	dest := manifest.LockPath(file)
+
+	log.Printf("writing lockfile: %s", dest)
+
+	if err := manifest.WriteLock(dest, lockfile, keys.LockfilePermissions); err != nil {
+		return err
+	}
+
+	fmt.Printf("locked %d packages in '%s'\n", len(lockfile.Packages), dest)
+
+	return nil
+}
+
+// lockPackage resolves release and asset of the package and calculates digest of the asset.
+func lockPackage(client *github.Client, p manifest.Package, opts assetOptions) (manifest.Locked, error) {
+	xlog.Push(p.Repo)
+	defer xlog.Pop()
+
+	pkg, err := packages.ParsePackage(p.Name())
+	if err != nil {
+		return manifest.Locked{}, fmt.Errorf("error parsing package '%s': %w", p.Name(), err)
+	}
+
+	pkg.Prerelease = p.Prerelease
+	if err := opts.setPattern(p.Asset); err != nil {
+		return manifest.Locked{}, err
+	}
+
+	asset, err := selectAsset(client, pkg, true, opts)
+	if err != nil {
+		return manifest.Locked{}, err
+	}
+
+	// Asset is downloaded, so the digest is calculated from its actual contents.
+	downloadPath := filepath.Join(keys.DownloadsPath, asset.Asset.GetName())
+
+	if err := downloadAsset(context.Background(), client, asset, downloadPath); err != nil {
+		return manifest.Locked{}, err
+	}
+
+	defer cleanupFile(downloadPath)
+
+	checksum, err := verifyChecksum(client, asset, downloadPath)
+	if err != nil {
+		return manifest.Locked{}, err
+	}
+
+	fmt.Printf("locked package '%s' to %s (%s)\n", p.Repo, asset.Release.GetTagName(), asset.Asset.GetName())
+
+	return manifest.Locked{
+		Repo:       p.Repo,
+		Version:    p.Version,
+		Pattern:    p.Asset,
+		Prerelease: p.Prerelease,
+		Tag:        asset.Release.GetTagName(),
+		Asset:      asset.Asset.GetName(),
+		URL:        asset.Asset.GetBrowserDownloadURL(),
+		SHA256:     checksum.digest,
+	}, nil
+}
+
+// lockedAsset returns release and asset recorded in lockfile.
+// It fails if they were removed from the repo or if asset URL doesn't match lockfile.
+func lockedAsset(client *github.Client, pkg packages.Package, locked manifest.Locked) (assets.AssetData, error) {
+	repo, _, err := client.Repositories.Get(context.Background(), pkg.Owner, pkg.Repo)
+	if err != nil {
+		return assets.AssetData{}, fmt.Errorf("error getting repository '%s': %w", locked.Repo, err)
+	}
+
+	release, _, err := client.Repositories.GetReleaseByTag(context.Background(), pkg.Owner, pkg.Repo, locked.Tag)
+	if err != nil {
+		return assets.AssetData{}, fmt.Errorf("error getting locked release '%s' of '%s': %w", locked.Tag, locked.Repo, err)
+	}
+
+	for _, asset := range release.Assets {
+		if asset.GetName() != locked.Asset {
+			continue
+		}
+
+		// Asset with the same name may be uploaded from another location, so it isn't trusted.
+		if asset.GetBrowserDownloadURL() != locked.URL {
+			return assets.AssetData{}, fmt.Errorf("URL of locked asset '%s' of '%s' was changed from %s to %s, "+
+				"run 'pmcli lock' to update lockfile", locked.Asset, locked.Repo, locked.URL, asset.GetBrowserDownloadURL())
+		}
+
+		return assets.AssetData{
+			Repository: repo,
+			Release:    release,
+			Asset:      asset,
+		}, nil
+	}
+
+	return assets.AssetData{}, fmt.Errorf("locked asset '%s' not found in release '%s' of '%s'",
+		locked.Asset, locked.Tag, locked.Repo)
+}
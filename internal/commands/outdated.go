@@ -0,0 +1,135 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/google/go-github/v39/github"
+	"github.com/iskorotkov/package-manager-cli/internal/keys"
+	"github.com/iskorotkov/package-manager-cli/internal/metadata"
+	"github.com/iskorotkov/package-manager-cli/pkg/packages"
+	"github.com/iskorotkov/package-manager-cli/pkg/xlog"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+const releaseDateLayout = "2006-01-02"
+
+//nolint:gochecknoinits
+func init() {
+	outdatedCmd := wrapCommand(&cobra.Command{ //nolint:exhaustivestruct
+		Use:          "outdated",
+		Short:        "show installed packages that have newer releases",
+		Args:         cobra.NoArgs,
+		RunE:         outdated,
+		SilenceUsage: true,
+	})
+
+	rootCmd.AddCommand(outdatedCmd)
+}
+
+func outdated(_ *cobra.Command, _ []string) error {
+	installed, err := metadata.ReadAll(keys.MetadataPath)
+	if err != nil {
+		return err
+	}
+
+	if len(installed) == 0 {
+		printNoPackagesInstalled()
+
+		return nil
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	t := createTable()
+	t.AppendHeader(table.Row{"repo", "installed", "latest", "released", "status"})
+
+	count := 0
+
+	// Packages that can't be checked (e. g. deleted repos) are reported in the table, so other packages are still checked.
+	var (
+		failed   []string
+		firstErr error
+	)
+
+	for _, m := range installed {
+		isOutdated, err := addOutdatedRow(t, client, m)
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("%s/%s", m.Package.Owner, m.Package.Repo))
+
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+
+		if isOutdated {
+			count++
+		}
+	}
+
+	t.Render()
+
+	if len(failed) > 0 {
+		return fmt.Errorf("%d of %d packages are outdated, error checking %d packages (%s): %w",
+			count, len(installed), len(failed), strings.Join(failed, ", "), firstErr)
+	}
+
+	if count > 0 {
+		return fmt.Errorf("%d of %d packages are outdated", count, len(installed))
+	}
+
+	fmt.Println("all packages are up to date")
+
+	return nil
+}
+
+// addOutdatedRow adds row with the latest release of the package to the table.
+// If the latest release can't be found, row with the error is added and the error is returned.
+func addOutdatedRow(t table.Writer, client *github.Client, m packages.Metadata) (bool, error) {
+	fullName := fmt.Sprintf("%s/%s", m.Package.Owner, m.Package.Repo)
+
+	xlog.Push(fullName)
+	defer xlog.Pop()
+
+	release, err := findLatestRelease(
+		client,
+		m.Package.Owner,
+		m.Package.Repo,
+		m.Package.Constraint,
+		m.Package.Prerelease,
+	)
+	if err != nil {
+		t.AppendRow(table.Row{fullName, m.Package.Version.Value, "-", "-", fmt.Sprintf("error: %v", err)})
+
+		return false, err
+	}
+
+	log.Printf("installed version: %s, latest version: %s", m.Package.Version.Value, release.GetTagName())
+
+	isOutdated := isNewer(release, m.Package.Version)
+
+	status := "up to date"
+
+	switch {
+	case isOutdated && m.Package.Pinned:
+		status = "pinned"
+		isOutdated = false
+	case isOutdated:
+		status = "outdated"
+	}
+
+	t.AppendRow(table.Row{
+		fullName,
+		m.Package.Version.Value,
+		release.GetTagName(),
+		release.GetPublishedAt().Format(releaseDateLayout),
+		status,
+	})
+
+	return isOutdated, nil
+}
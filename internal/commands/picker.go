@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-github/v39/github"
+	"github.com/iskorotkov/package-manager-cli/pkg/assets"
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+// isTerminal reports whether stdin is a terminal, so user can be asked questions.
+func isTerminal() bool {
+	info, err := os.Stdin.Stat()
+
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// pickAsset prints numbered list of ranked release assets and asks user to choose one of them.
+func pickAsset(release *github.RepositoryRelease, ranked []assets.Score) (*github.ReleaseAsset, error) {
+	if len(ranked) == 0 {
+		return nil, fmt.Errorf("release %s doesn't have assets", release.GetTagName())
+	}
+
+	fmt.Printf("can't select asset of release %s automatically, choose one of the assets:\n", release.GetTagName())
+
+	t := createTable()
+	t.AppendHeader(table.Row{"#", "asset", "os", "arch"})
+
+	for i, s := range ranked {
+		t.AppendRow(table.Row{i + 1, s.Asset.GetName(), s.Platform.OS, s.Platform.Arch})
+	}
+
+	t.Render()
+
+	r := bufio.NewReader(os.Stdin)
+
+	for {
+		fmt.Printf("asset number [1-%d]: ", len(ranked))
+
+		line, err := r.ReadString('\n')
+		if errors.Is(err, io.EOF) && line == "" {
+			return nil, fmt.Errorf("asset wasn't selected")
+		} else if err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("error reading selected asset: %w", err)
+		}
+
+		n, err := strconv.Atoi(strings.TrimSpace(line))
+		if err != nil || n < 1 || n > len(ranked) {
+			fmt.Printf("invalid asset number: %q\n", strings.TrimSpace(line))
+
+			continue
+		}
+
+		asset := ranked[n-1].Asset
+
+		log.Printf("selected asset: %s", asset.GetName())
+
+		return asset, nil
+	}
+}
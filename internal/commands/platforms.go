@@ -0,0 +1,174 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/iskorotkov/package-manager-cli/pkg/assets"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+// assetOptions control how release assets are selected.
+type assetOptions struct {
+	// pattern is used to select asset instead of platform detection if set.
+	pattern *assets.Pattern
+	// platforms are used to select asset from the most to the least preferred.
+	platforms []assets.Platform
+	// libc is used to select Linux builds.
+	libc assets.Libc
+	// explain enables printing of asset scores.
+	explain bool
+	// interactive enables asking user to choose asset if it can't be selected automatically.
+	interactive bool
+}
+
+// addAssetFlags adds flags that control asset selection.
+func addAssetFlags(cmd *cobra.Command) {
+	cmd.Flags().String("os", "", "select assets for OS instead of the current one (e. g. linux, darwin, windows)")
+	cmd.Flags().String("arch", "", "select assets for arch instead of the current one (e. g. amd64, arm64)")
+	cmd.Flags().String("libc", "", "select Linux assets for libc instead of the current one (glibc or musl)")
+	cmd.Flags().Bool("explain", false, "print scores of release assets explaining why asset was selected")
+	cmd.Flags().Bool("non-interactive", false, "select asset automatically instead of asking when it's ambiguous")
+}
+
+// readAssetOptions reads flags that control asset selection. Asset pattern isn't set.
+func readAssetOptions(cmd *cobra.Command) (assetOptions, error) {
+	platforms, host, err := readPlatforms(cmd)
+	if err != nil {
+		return assetOptions{}, err
+	}
+
+	libc, err := readLibc(cmd, host)
+	if err != nil {
+		return assetOptions{}, err
+	}
+
+	explain, err := cmd.Flags().GetBool("explain")
+	if err != nil {
+		return assetOptions{}, fmt.Errorf("error reading flag value: %w", err)
+	}
+
+	nonInteractive, err := cmd.Flags().GetBool("non-interactive")
+	if err != nil {
+		return assetOptions{}, fmt.Errorf("error reading flag value: %w", err)
+	}
+
+	return assetOptions{
+		pattern:     nil,
+		platforms:   platforms,
+		libc:        libc,
+		explain:     explain,
+		interactive: !nonInteractive,
+	}, nil
+}
+
+// setPattern parses asset pattern and uses it to select assets. Empty pattern enables platform detection.
+func (o *assetOptions) setPattern(s string) error {
+	if s == "" {
+		o.pattern = nil
+
+		return nil
+	}
+
+	pattern, err := assets.ParsePattern(s)
+	if err != nil {
+		return err
+	}
+
+	o.pattern = &pattern
+
+	return nil
+}
+
+// readPlatforms returns platforms of the host or platforms for OS and arch set with flags.
+// It also reports whether platforms are the host ones.
+func readPlatforms(cmd *cobra.Command) ([]assets.Platform, bool, error) {
+	os, err := cmd.Flags().GetString("os")
+	if err != nil {
+		return nil, false, fmt.Errorf("error reading flag value: %w", err)
+	}
+
+	arch, err := cmd.Flags().GetString("arch")
+	if err != nil {
+		return nil, false, fmt.Errorf("error reading flag value: %w", err)
+	}
+
+	if os == "" && arch == "" {
+		platforms := assets.HostPlatforms()
+
+		log.Printf("host platforms: %+v", platforms)
+
+		return platforms, true, nil
+	}
+
+	if os == "" {
+		os = runtime.GOOS
+	}
+
+	if arch == "" {
+		arch = runtime.GOARCH
+	}
+
+	platforms, err := assets.Platforms(os, arch)
+	if err != nil {
+		return nil, false, err
+	}
+
+	log.Printf("platforms for %s/%s: %+v", os, arch, platforms)
+
+	return platforms, false, nil
+}
+
+// readLibc returns libc set with flag. Otherwise, it returns libc of the host if assets are selected for the host,
+// and glibc if they are selected for another machine, as the host can't be inspected in this case.
+func readLibc(cmd *cobra.Command, host bool) (assets.Libc, error) {
+	value, err := cmd.Flags().GetString("libc")
+	if err != nil {
+		return "", fmt.Errorf("error reading flag value: %w", err)
+	}
+
+	if value != "" {
+		libc, err := assets.ParseLibc(value)
+		if err != nil {
+			return "", err
+		}
+
+		return libc, nil
+	}
+
+	if !host {
+		return assets.LibcGlibc, nil
+	}
+
+	libc := assets.HostLibc()
+
+	log.Printf("host libc: %s", libc)
+
+	return libc, nil
+}
+
+// printScores prints scores of release assets from the best to the worst.
+func printScores(scores []assets.Score) {
+	t := createTable()
+	t.AppendHeader(table.Row{"asset", "platform", "points", "reasons"})
+
+	for _, s := range scores {
+		points := strconv.Itoa(s.Points)
+		if s.Excluded {
+			points = "-"
+		}
+
+		t.AppendRow(table.Row{
+			s.Asset.GetName(),
+			fmt.Sprintf("%s/%s", s.Platform.OS, s.Platform.Arch),
+			points,
+			strings.Join(s.Reasons, ", "),
+		})
+	}
+
+	t.Render()
+}
@@ -0,0 +1,69 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/go-github/v39/github"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+)
+
+//nolint:gochecknoinits
+func init() {
+	rateLimitCmd := wrapCommand(&cobra.Command{ //nolint:exhaustivestruct
+		Use:   "ratelimit",
+		Short: "show remaining GitHub API quota",
+		Args:  cobra.NoArgs,
+		RunE:  rateLimit,
+	})
+
+	rootCmd.AddCommand(rateLimitCmd)
+}
+
+func rateLimit(_ *cobra.Command, _ []string) error {
+	token, err := findToken()
+	if err != nil {
+		return err
+	}
+
+	client, err := newClientWithToken(token)
+	if err != nil {
+		return err
+	}
+
+	// Requests to rate limit endpoint don't count against the quota.
+	limits, _, err := client.RateLimits(context.Background())
+	if err != nil {
+		return fmt.Errorf("error getting rate limits: %w", err)
+	}
+
+	log.Printf("rate limits: %+v", limits)
+
+	fmt.Printf("authentication: %s\n", token.Source)
+
+	t := createTable()
+	t.AppendHeader(table.Row{"resource", "limit", "remaining", "reset"})
+
+	appendRateRow(t, "core", limits.GetCore())
+	appendRateRow(t, "search", limits.GetSearch())
+
+	t.Render()
+
+	return nil
+}
+
+func appendRateRow(t table.Writer, resource string, rate *github.Rate) {
+	if rate == nil {
+		return
+	}
+
+	t.AppendRow(table.Row{
+		resource,
+		rate.Limit,
+		rate.Remaining,
+		fmt.Sprintf("%s (in %s)", rate.Reset.Local().Format(time.Kitchen), formatDuration(time.Until(rate.Reset.Time))),
+	})
+}
@@ -0,0 +1,189 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v39/github"
+	"github.com/iskorotkov/package-manager-cli/pkg/packages"
+)
+
+const (
+	releasesPerPage = 100
+	releasesPages   = 5
+)
+
+// findRelease returns the release with the specified tag or the latest release matching constraint
+// if version is empty. Empty constraint matches any release. Prereleases are selected only if prerelease is set.
+// Tags are matched with and without "v" prefix, so both "1.2.3" and "v1.2.3" can be used.
+func findRelease(
+	client *github.Client,
+	owner string,
+	name string,
+	version packages.Version,
+	constraint string,
+	prerelease bool,
+) (*github.RepositoryRelease, error) {
+	if version.Value == "" {
+		return findLatestRelease(client, owner, name, constraint, prerelease)
+	}
+
+	tags := []string{version.Value}
+	if strings.HasPrefix(version.Value, "v") {
+		tags = append(tags, strings.TrimPrefix(version.Value, "v"))
+	} else {
+		tags = append(tags, "v"+version.Value)
+	}
+
+	for _, tag := range tags {
+		release, resp, err := client.Repositories.GetReleaseByTag(context.Background(), owner, name, tag)
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			log.Printf("release with tag '%s' not found", tag)
+
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("error getting release '%s': %w", tag, err)
+		}
+
+		return release, nil
+	}
+
+	return nil, fmt.Errorf("release '%s' not found in '%s/%s'", version.Value, owner, name)
+}
+
+func findLatestRelease(
+	client *github.Client,
+	owner, name, constraint string,
+	prerelease bool,
+) (*github.RepositoryRelease, error) {
+	c, err := parseConstraint(constraint)
+	if err != nil {
+		return nil, err
+	}
+
+	releases, err := listReleases(client, owner, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(releases) == 0 {
+		return nil, fmt.Errorf("no releases available")
+	}
+
+	latest := latestRelease(releases, c, prerelease)
+	if latest == nil {
+		return nil, fmt.Errorf("no releases matching '%s' in '%s/%s'", constraint, owner, name)
+	}
+
+	return latest, nil
+}
+
+// listReleases returns up to releasesPages pages of releases.
+func listReleases(client *github.Client, owner, name string) ([]*github.RepositoryRelease, error) {
+	var result []*github.RepositoryRelease
+
+	opts := &github.ListOptions{PerPage: releasesPerPage} //nolint:exhaustivestruct
+
+	for page := 0; page < releasesPages; page++ {
+		releases, resp, err := client.Repositories.ListReleases(context.Background(), owner, name, opts)
+		if err != nil {
+			return nil, fmt.Errorf("error getting releases: %w", err)
+		}
+
+		result = append(result, releases...)
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opts.Page = resp.NextPage
+	}
+
+	log.Printf("got releases: %d", len(result))
+
+	return result, nil
+}
+
+// latestRelease returns the release with the highest version matching constraint.
+// GitHub sorts releases by creation date, so it can't be used to select the latest version.
+// If constraint is empty and no release has a valid version, the first allowed release is returned.
+func latestRelease(
+	releases []*github.RepositoryRelease,
+	constraint packages.Constraint,
+	prerelease bool,
+) *github.RepositoryRelease {
+	var latest, first *github.RepositoryRelease
+
+	for _, release := range releases {
+		if !isAllowed(release, constraint, prerelease) {
+			continue
+		}
+
+		if first == nil {
+			first = release
+		}
+
+		// Tags that can't be parsed can't be compared, so they are used only as a fallback.
+		version := releaseVersion(release)
+		if version.Components == nil || !constraint.Matches(version) {
+			continue
+		}
+
+		if latest == nil || isNewer(release, releaseVersion(latest)) {
+			latest = release
+		}
+	}
+
+	if latest == nil && constraint.Value == "" {
+		return first
+	}
+
+	return latest
+}
+
+// isAllowed reports whether release can be selected as the latest one. Drafts are never selected.
+// Prereleases (marked on GitHub or with prerelease tags like "v1.2.0-rc.1") are selected only if prerelease is set
+// or if constraint explicitly mentions them.
+func isAllowed(release *github.RepositoryRelease, constraint packages.Constraint, prerelease bool) bool {
+	if release.GetDraft() {
+		return false
+	}
+
+	version := releaseVersion(release)
+
+	if !release.GetPrerelease() && !version.IsPrerelease() {
+		return true
+	}
+
+	return prerelease || version.IsPrerelease() && constraint.Value != "" && constraint.Matches(version)
+}
+
+// isNewer reports whether release has higher version than the installed one.
+func isNewer(release *github.RepositoryRelease, installed packages.Version) bool {
+	// Versions saved by older versions of the app don't have parsed components.
+	installed, _ = packages.ParseVersion(installed.Value)
+
+	return packages.Less(installed, releaseVersion(release))
+}
+
+func releaseVersion(release *github.RepositoryRelease) packages.Version {
+	version, _ := packages.ParseVersion(release.GetTagName())
+
+	return version
+}
+
+func parseConstraint(constraint string) (packages.Constraint, error) {
+	if constraint == "" {
+		return packages.Constraint{}, nil //nolint:exhaustivestruct
+	}
+
+	c, err := packages.ParseConstraint(constraint)
+	if err != nil {
+		return packages.Constraint{}, fmt.Errorf("error parsing version constraint: %w", err)
+	}
+
+	return c, nil
+}
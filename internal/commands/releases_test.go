@@ -0,0 +1,140 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v39/github"
+	"github.com/iskorotkov/package-manager-cli/pkg/packages"
+)
+
+type testRelease struct {
+	tag        string
+	prerelease bool
+	draft      bool
+}
+
+func TestLatestRelease(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		releases   []testRelease
+		constraint string
+		prerelease bool
+		want       string
+	}{
+		{
+			name:     "highest version out of order",
+			releases: []testRelease{{tag: "v1.9.0"}, {tag: "v1.10.0"}, {tag: "v1.2.0"}},
+			want:     "v1.10.0",
+		},
+		{
+			name:       "highest version matching constraint",
+			releases:   []testRelease{{tag: "v2.0.0"}, {tag: "v1.2.0"}, {tag: "v1.10.0"}, {tag: "v0.9.0"}},
+			constraint: "^1.2",
+			want:       "v1.10.0",
+		},
+		{
+			name:       "no versions matching constraint",
+			releases:   []testRelease{{tag: "v2.0.0"}, {tag: "v0.9.0"}},
+			constraint: "^1.2",
+			want:       "",
+		},
+		{
+			name:     "prerelease tags are excluded by default",
+			releases: []testRelease{{tag: "v2.0.0-rc.1"}, {tag: "v1.0.0"}},
+			want:     "v1.0.0",
+		},
+		{
+			name:     "releases marked as prereleases are excluded by default",
+			releases: []testRelease{{tag: "v2.0.0", prerelease: true}, {tag: "v1.0.0"}},
+			want:     "v1.0.0",
+		},
+		{
+			name:       "prereleases are included if allowed",
+			releases:   []testRelease{{tag: "v2.0.0-rc.1"}, {tag: "v1.0.0"}, {tag: "v1.5.0", prerelease: true}},
+			prerelease: true,
+			want:       "v2.0.0-rc.1",
+		},
+		{
+			name:       "prereleases are included if constraint mentions them",
+			releases:   []testRelease{{tag: "v2.0.0-rc.2"}, {tag: "v2.0.0-rc.1"}, {tag: "v1.0.0"}},
+			constraint: ">=2.0.0-rc.1",
+			want:       "v2.0.0-rc.2",
+		},
+		{
+			name:     "release is newer than its prereleases",
+			releases: []testRelease{{tag: "v2.0.0-rc.1"}, {tag: "v2.0.0"}},
+			want:     "v2.0.0",
+		},
+		{
+			name:       "drafts are always skipped",
+			releases:   []testRelease{{tag: "v3.0.0", draft: true}, {tag: "v2.0.0-rc.1", draft: true}, {tag: "v1.0.0"}},
+			prerelease: true,
+			want:       "v1.0.0",
+		},
+		{
+			name:     "first release if no tag can be parsed",
+			releases: []testRelease{{tag: "nightly-draft", draft: true}, {tag: "nightly"}, {tag: "stable"}},
+			want:     "nightly",
+		},
+		{
+			name:     "unparsed tags are older than parsed ones",
+			releases: []testRelease{{tag: "nightly"}, {tag: "v0.1.0"}},
+			want:     "v0.1.0",
+		},
+		{
+			name:       "no fallback with constraint",
+			releases:   []testRelease{{tag: "nightly"}, {tag: "stable"}},
+			constraint: "^1.0",
+			want:       "",
+		},
+		{
+			name:     "no allowed releases",
+			releases: []testRelease{{tag: "v1.0.0", draft: true}, {tag: "v2.0.0", prerelease: true}},
+			want:     "",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			releases := make([]*github.RepositoryRelease, 0, len(tt.releases))
+			for _, r := range tt.releases {
+				releases = append(releases, &github.RepositoryRelease{ //nolint:exhaustivestruct
+					TagName:    github.String(r.tag),
+					Prerelease: github.Bool(r.prerelease),
+					Draft:      github.Bool(r.draft),
+				})
+			}
+
+			c, err := parseConstraint(tt.constraint)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := latestRelease(releases, c, tt.prerelease)
+			if got.GetTagName() != tt.want {
+				t.Errorf("latestRelease() = %q, want %q", got.GetTagName(), tt.want)
+			}
+		})
+	}
+}
+
+func TestIsNewer(t *testing.T) {
+	t.Parallel()
+
+	release := &github.RepositoryRelease{TagName: github.String("v1.10.0")} //nolint:exhaustivestruct
+
+	// Versions saved by older versions of the app don't have parsed components.
+	if !isNewer(release, packages.Version{Value: "v1.9.0"}) { //nolint:exhaustivestruct
+		t.Errorf("isNewer() = false, want true")
+	}
+
+	if isNewer(release, packages.Version{Value: "1.10.0"}) { //nolint:exhaustivestruct
+		t.Errorf("isNewer() = true, want false")
+	}
+}
@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/iskorotkov/package-manager-cli/internal/keys"
+	"github.com/iskorotkov/package-manager-cli/internal/metadata"
+	"github.com/iskorotkov/package-manager-cli/pkg/packages"
+	"github.com/iskorotkov/package-manager-cli/pkg/xlog"
+	"github.com/spf13/cobra"
+)
+
+//nolint:gochecknoinits
+func init() {
+	rollbackCmd := wrapCommand(&cobra.Command{ //nolint:exhaustivestruct
+		Use:   "rollback",
+		Short: "switch package back to the previously installed version",
+		Args:  cobra.ExactArgs(1),
+		RunE:  rollback,
+		// Errors are caused by missing versions, not by invalid usage.
+		SilenceUsage: true,
+	})
+
+	rootCmd.AddCommand(rollbackCmd)
+}
+
+func rollback(_ *cobra.Command, args []string) error {
+	packageName := args[0]
+
+	xlog.Push(packageName)
+	defer xlog.Pop()
+
+	log.Printf("package name: %s", packageName)
+
+	pkg, err := packages.ParsePackage(packageName)
+	if err != nil {
+		return fmt.Errorf("error parsing package name: %w", err)
+	}
+
+	installed, err := metadata.ReadAll(keys.MetadataPath)
+	if err != nil {
+		return err
+	}
+
+	m, ok := findInstalled(installed, pkg, hasOwner(packageName))
+	if !ok {
+		printPackageNotInstalled(packageName)
+
+		return nil
+	}
+
+	fullName := fmt.Sprintf("%s/%s", m.Package.Owner, m.Package.Repo)
+
+	log.Printf("version history: %+v", m.Installation.History)
+
+	previous, index, ok := m.Previous()
+	if !ok {
+		return fmt.Errorf("package '%s' doesn't have previous versions to roll back to", fullName)
+	}
+
+	// Entries after the previous version are dropped, so repeated rollbacks go further back in history.
+	history := m.Installation.History[:index]
+	current := m.Package.Version.Value
+
+	tx := newTransaction()
+	defer tx.rollback()
+
+	if err := activateVersion(tx, &m, previous, ""); err != nil {
+		return err
+	}
+
+	m.Installation.History = history
+	// Otherwise the next upgrade would install the version that was rolled back.
+	m.Package.Pinned = true
+
+	if err := metadata.Save(keys.MetadataPath, m, keys.MetadataPermissions); err != nil {
+		return fmt.Errorf("error saving package metadata: %w", err)
+	}
+
+	tx.commit()
+
+	fmt.Printf("rolled back package '%s' from %s to %s\n", fullName, current, previous.Version.Value)
+
+	return nil
+}
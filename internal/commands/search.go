@@ -31,7 +31,10 @@ func search(_ *cobra.Command, args []string) error {
 
 	log.Printf("package name: %s", packageName)
 
-	client := github.NewClient(nil)
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
 
 	result, _, err := client.Search.Repositories(context.Background(), packageName, nil)
 	if err != nil {
@@ -0,0 +1,275 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/google/go-github/v39/github"
+	"github.com/iskorotkov/package-manager-cli/internal/keys"
+	"github.com/iskorotkov/package-manager-cli/internal/manifest"
+	"github.com/iskorotkov/package-manager-cli/internal/metadata"
+	"github.com/iskorotkov/package-manager-cli/pkg/assets"
+	"github.com/iskorotkov/package-manager-cli/pkg/packages"
+	"github.com/iskorotkov/package-manager-cli/pkg/xlog"
+	"github.com/spf13/cobra"
+)
+
+//nolint:gochecknoinits
+func init() {
+	syncCmd := wrapCommand(&cobra.Command{ //nolint:exhaustivestruct
+		Use:   "sync",
+		Short: "install packages listed in manifest file",
+		Args:  cobra.NoArgs,
+		RunE:  sync,
+	})
+
+	syncCmd.Flags().StringP("file", "f", manifest.DefaultPath, "path to manifest file")
+	syncCmd.Flags().Bool("prune", false, "uninstall packages that aren't listed in manifest")
+	syncCmd.Flags().Bool("frozen", false, "install exact releases and assets from lockfile")
+	syncCmd.Flags().Bool("require-signature", false, "fail if release signature can't be verified with trusted keys")
+	addAssetFlags(syncCmd)
+
+	rootCmd.AddCommand(syncCmd)
+}
+
+func sync(cmd *cobra.Command, _ []string) error {
+	file, err := cmd.Flags().GetString("file")
+	if err != nil {
+		return fmt.Errorf("error reading flag value: %w", err)
+	}
+
+	prune, err := cmd.Flags().GetBool("prune")
+	if err != nil {
+		return fmt.Errorf("error reading flag value: %w", err)
+	}
+
+	frozen, err := cmd.Flags().GetBool("frozen")
+	if err != nil {
+		return fmt.Errorf("error reading flag value: %w", err)
+	}
+
+	requireSignature, err := cmd.Flags().GetBool("require-signature")
+	if err != nil {
+		return fmt.Errorf("error reading flag value: %w", err)
+	}
+
+	assetOpts, err := readAssetOptions(cmd)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("reading manifest: %s", file)
+
+	mf, err := manifest.Read(file)
+	if err != nil {
+		return err
+	}
+
+	var lockfile manifest.Lockfile
+
+	if frozen {
+		lockfile, err = manifest.ReadLock(manifest.LockPath(file))
+		if err != nil {
+			return err
+		}
+	}
+
+	installed, err := metadata.ReadAll(keys.MetadataPath)
+	if err != nil {
+		return err
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	opts := installOptions{
+		desktop:          false,
+		requireSignature: requireSignature,
+		asset:            assetOpts,
+		sha256:           "",
+	}
+
+	for _, p := range mf.Packages {
+		if frozen {
+			err = syncLocked(client, installed, p, lockfile, opts)
+		} else {
+			err = syncPackage(client, installed, p, opts)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return syncUnlisted(installed, mf, prune)
+}
+
+// syncPackage installs package listed in manifest if it isn't installed or its version doesn't match manifest.
+// Desktop integration and asset pattern from manifest override opts.
+func syncPackage(
+	client *github.Client,
+	installed []packages.Metadata,
+	p manifest.Package,
+	opts installOptions,
+) error {
+	xlog.Push(p.Repo)
+	defer xlog.Pop()
+
+	pkg, err := packages.ParsePackage(p.Name())
+	if err != nil {
+		return fmt.Errorf("error parsing package '%s': %w", p.Name(), err)
+	}
+
+	pkg.Prerelease = p.Prerelease
+	pkg.AssetPattern = p.Asset
+
+	log.Printf("parsed package: %+v", pkg)
+
+	if err := opts.asset.setPattern(p.Asset); err != nil {
+		return err
+	}
+
+	m, ok := findInstalled(installed, pkg, true)
+	if ok && satisfies(m.Package.Version, pkg) && matchesAsset(m, opts.asset.pattern) {
+		fmt.Printf("package '%s' is up to date (%s)\n", p.Repo, m.Package.Version.Value)
+
+		return updateRequirements(m, pkg)
+	}
+
+	if ok {
+		log.Printf("installed version %s or asset %s doesn't match manifest", m.Package.Version.Value, m.Asset.Name)
+	}
+
+	opts.desktop = p.Desktop
+
+	// Install flow also switches to other versions and downgrades packages.
+	return installPackage(client, pkg, true, opts)
+}
+
+// syncLocked installs exact release and asset of the package from lockfile.
+// It fails if lockfile doesn't match manifest or if locked asset was changed.
+func syncLocked(
+	client *github.Client,
+	installed []packages.Metadata,
+	p manifest.Package,
+	lockfile manifest.Lockfile,
+	opts installOptions,
+) error {
+	xlog.Push(p.Repo)
+	defer xlog.Pop()
+
+	locked, ok := lockfile.Find(p.Repo)
+	if !ok || !locked.Matches(p) {
+		return fmt.Errorf("lockfile is outdated for package '%s', run 'pmcli lock' to update it", p.Repo)
+	}
+
+	log.Printf("locked package: %+v", locked)
+
+	pkg, err := packages.ParsePackage(p.Name())
+	if err != nil {
+		return fmt.Errorf("error parsing package '%s': %w", p.Name(), err)
+	}
+
+	pkg.Prerelease = p.Prerelease
+	pkg.AssetPattern = p.Asset
+
+	m, ok := findInstalled(installed, pkg, true)
+	if ok && m.Package.Version.Value == locked.Tag && m.Asset.SHA256 == locked.SHA256 {
+		fmt.Printf("package '%s' is up to date (%s)\n", p.Repo, m.Package.Version.Value)
+
+		return updateRequirements(m, pkg)
+	}
+
+	asset, err := lockedAsset(client, pkg, locked)
+	if err != nil {
+		return err
+	}
+
+	opts.desktop = p.Desktop
+	opts.sha256 = locked.SHA256
+
+	return installSelected(client, pkg, asset, opts)
+}
+
+// satisfies reports whether version matches exact version or constraint of the package.
+// Any version matches package without version and constraint.
+func satisfies(v packages.Version, pkg packages.Package) bool {
+	switch {
+	case pkg.Version.Value != "":
+		return v.Value == pkg.Version.Value ||
+			v.Components != nil && pkg.Version.Components != nil && packages.Compare(v, pkg.Version) == 0
+	case pkg.Constraint != "":
+		c, err := packages.ParseConstraint(pkg.Constraint)
+
+		return err == nil && c.Matches(v)
+	default:
+		return true
+	}
+}
+
+// matchesAsset reports whether installed asset was selected with asset pattern from manifest or matches it.
+// Asset selected with a pattern is kept if the pattern is removed from manifest.
+func matchesAsset(m packages.Metadata, pattern *assets.Pattern) bool {
+	if pattern == nil || m.Package.AssetPattern == pattern.String() {
+		return true
+	}
+
+	return pattern.Matches(m.Asset.Name)
+}
+
+// updateRequirements saves version requirements and asset pattern of the package from manifest
+// if they were changed.
+func updateRequirements(m packages.Metadata, pkg packages.Package) error {
+	if m.Package.Pinned == pkg.Pinned && m.Package.Constraint == pkg.Constraint &&
+		m.Package.Prerelease == pkg.Prerelease && m.Package.AssetPattern == pkg.AssetPattern {
+		return nil
+	}
+
+	m.Package.Pinned = pkg.Pinned
+	m.Package.Constraint = pkg.Constraint
+	m.Package.Prerelease = pkg.Prerelease
+	m.Package.AssetPattern = pkg.AssetPattern
+
+	if err := metadata.Save(keys.MetadataPath, m, keys.MetadataPermissions); err != nil {
+		return fmt.Errorf("error saving package metadata: %w", err)
+	}
+
+	return nil
+}
+
+// syncUnlisted uninstalls packages that aren't listed in manifest if prune is set, and reports them otherwise.
+func syncUnlisted(installed []packages.Metadata, mf manifest.Manifest, prune bool) error {
+	for i := range installed {
+		m := installed[i]
+		fullName := fmt.Sprintf("%s/%s", m.Package.Owner, m.Package.Repo)
+
+		if isListed(mf, fullName) {
+			continue
+		}
+
+		if !prune {
+			fmt.Printf("package '%s' isn't listed in manifest, use --prune to uninstall it\n", fullName)
+
+			continue
+		}
+
+		if err := uninstallPackage(&m); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func isListed(mf manifest.Manifest, fullName string) bool {
+	for _, p := range mf.Packages {
+		if strings.EqualFold(p.Repo, fullName) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,112 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// stashSuffix is added to files and folders that are replaced during installation.
+const stashSuffix = ".pm-backup"
+
+// transaction tracks changes made during package installation and reverts them if installation fails.
+// It also intercepts interrupt signals, so interrupted installation is reverted instead of being left halfway.
+type transaction struct {
+	ctx     context.Context
+	stop    context.CancelFunc
+	undo    []func() error
+	cleanup []func() error
+	done    bool
+}
+
+func newTransaction() *transaction {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+
+	return &transaction{ //nolint:exhaustivestruct
+		ctx:  ctx,
+		stop: stop,
+	}
+}
+
+// check returns error if installation was interrupted.
+func (t *transaction) check() error {
+	if err := t.ctx.Err(); err != nil {
+		return fmt.Errorf("installation interrupted: %w", err)
+	}
+
+	return nil
+}
+
+// created registers file or folder that must be removed on rollback.
+func (t *transaction) created(path string) {
+	t.onRollback(func() error {
+		return os.RemoveAll(path)
+	})
+}
+
+// onRollback registers function that reverts a change. Functions are called in reverse order.
+func (t *transaction) onRollback(f func() error) {
+	t.undo = append(t.undo, f)
+}
+
+// stash moves file or folder aside, so it can be restored on rollback or removed on commit.
+func (t *transaction) stash(path string) error {
+	stashed := path + stashSuffix
+
+	if err := os.Rename(path, stashed); errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("error moving '%s' aside: %w", path, err)
+	}
+
+	log.Printf("stashed: %s", path)
+
+	t.onRollback(func() error {
+		return os.Rename(stashed, path)
+	})
+
+	t.cleanup = append(t.cleanup, func() error {
+		return os.RemoveAll(stashed)
+	})
+
+	return nil
+}
+
+// commit finishes transaction and removes stashed files.
+func (t *transaction) commit() {
+	if t.done {
+		return
+	}
+
+	t.done = true
+	t.stop()
+
+	for _, f := range t.cleanup {
+		if err := f(); err != nil {
+			log.Printf("error removing stashed file: %v", err)
+		}
+	}
+}
+
+// rollback reverts all registered changes unless transaction was committed.
+func (t *transaction) rollback() {
+	if t.done {
+		return
+	}
+
+	t.done = true
+	t.stop()
+
+	log.Printf("rolling back %d changes", len(t.undo))
+
+	for i := len(t.undo) - 1; i >= 0; i-- {
+		if err := t.undo[i](); err != nil {
+			log.Printf("error rolling back change: %v", err)
+			fmt.Printf("error rolling back change: %v\n", err)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/iskorotkov/package-manager-cli/internal/keys"
+	"github.com/iskorotkov/package-manager-cli/pkg/signatures"
+	"github.com/iskorotkov/package-manager-cli/pkg/xlog"
+	"github.com/spf13/cobra"
+)
+
+//nolint:gochecknoinits
+func init() {
+	trustCmd := wrapCommand(&cobra.Command{ //nolint:exhaustivestruct
+		Use:   "trust",
+		Short: "trust public key for verifying releases of repo owner",
+		Args:  cobra.ExactArgs(2), //nolint:gomnd
+		RunE:  trust,
+	})
+
+	trustCmd.Flags().String("type", "", "key type: minisign, cosign or gpg (detected automatically if empty)")
+
+	untrustCmd := wrapCommand(&cobra.Command{ //nolint:exhaustivestruct
+		Use:   "untrust",
+		Short: "remove all trusted public keys of repo owner",
+		Args:  cobra.ExactArgs(1),
+		RunE:  untrust,
+	})
+
+	rootCmd.AddCommand(trustCmd)
+	rootCmd.AddCommand(untrustCmd)
+}
+
+func trust(cmd *cobra.Command, args []string) error {
+	owner, keyFile := args[0], args[1]
+
+	xlog.Push(owner)
+	defer xlog.Pop()
+
+	keyType, err := cmd.Flags().GetString("type")
+	if err != nil {
+		return fmt.Errorf("error reading flag value: %w", err)
+	}
+
+	b, err := os.ReadFile(keyFile)
+	if err != nil {
+		return fmt.Errorf("error reading key file: %w", err)
+	}
+
+	key := signatures.Key{
+		Type:  signatures.Type(keyType),
+		Value: string(b),
+	}
+
+	if key.Type == signatures.TypeUnknown {
+		key.Type = signatures.DetectKeyType(key.Value)
+	}
+
+	switch key.Type {
+	case signatures.TypeMinisign, signatures.TypeCosign, signatures.TypeGPG:
+	case signatures.TypeUnknown:
+		return fmt.Errorf("can't detect key type, use --type flag")
+	default:
+		return fmt.Errorf("unsupported key type '%s'", key.Type)
+	}
+
+	log.Printf("key type: %s", key.Type)
+
+	keyring, err := signatures.LoadKeyring(keys.KeyringPath)
+	if err != nil {
+		return err
+	}
+
+	keyring.Trust(owner, key)
+
+	if err := signatures.SaveKeyring(keys.KeyringPath, keyring, keys.KeyringPermissions); err != nil {
+		return err
+	}
+
+	fmt.Printf("trusted %s key for '%s'\n", key.Type, owner)
+
+	return nil
+}
+
+func untrust(_ *cobra.Command, args []string) error {
+	owner := args[0]
+
+	xlog.Push(owner)
+	defer xlog.Pop()
+
+	keyring, err := signatures.LoadKeyring(keys.KeyringPath)
+	if err != nil {
+		return err
+	}
+
+	removed := keyring.Untrust(owner)
+
+	if err := signatures.SaveKeyring(keys.KeyringPath, keyring, keys.KeyringPermissions); err != nil {
+		return err
+	}
+
+	fmt.Printf("removed %d keys of '%s'\n", removed, owner)
+
+	return nil
+}
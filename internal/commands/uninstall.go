@@ -3,11 +3,10 @@ package commands
 import (
 	"errors"
 	"fmt"
-	"io/fs"
-	"io/ioutil"
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/iskorotkov/package-manager-cli/internal/keys"
 	"github.com/iskorotkov/package-manager-cli/internal/metadata"
@@ -36,21 +35,6 @@ func uninstall(_ *cobra.Command, args []string) error {
 
 	log.Printf("package name: %s", packageName)
 
-	dir, err := ioutil.ReadDir(keys.MetadataPath)
-	if errors.Is(err, os.ErrNotExist) {
-		printPackageNotInstalled(packageName)
-
-		return nil
-	} else if err != nil {
-		return fmt.Errorf("error opening metadata folder: %w", err)
-	}
-
-	if len(dir) == 0 {
-		printPackageNotInstalled(packageName)
-
-		return nil
-	}
-
 	pkg, err := packages.ParsePackage(packageName)
 	if err != nil {
 		return fmt.Errorf("error parsing package name: %w", err)
@@ -58,61 +42,87 @@ func uninstall(_ *cobra.Command, args []string) error {
 
 	log.Printf("pkg package name as package metadata: %+v", pkg)
 
-	packageMetadata, path, err := findPackageMetadata(pkg, dir)
+	installed, err := metadata.ReadAll(keys.MetadataPath)
 	if err != nil {
 		return err
 	}
 
-	if packageMetadata == nil {
+	if !hasOwner(packageName) && countInstalled(installed, pkg.Repo) > 1 {
+		return fmt.Errorf("several packages named '%s' are installed, specify owner (e. g. owner/%s)", pkg.Repo, pkg.Repo)
+	}
+
+	m, ok := findInstalled(installed, pkg, hasOwner(packageName))
+	if !ok {
 		printPackageNotInstalled(packageName)
 
 		return nil
 	}
 
-	if path == "" {
-		return fmt.Errorf("empty metadata file path")
+	if pkg.Constraint != "" {
+		return fmt.Errorf("version constraints can't be used to uninstall packages")
 	}
 
-	if err := os.Remove(path); err != nil {
-		return fmt.Errorf("error removing metadata file: %w", err)
+	if pkg.Version.Value != "" {
+		return uninstallVersion(&m, pkg.Version.Value)
 	}
 
+	return uninstallPackage(&m)
+}
+
+// uninstallPackage removes all files of the package and its metadata file.
+// Metadata is removed last, so uninstallation can be retried if files can't be removed.
+func uninstallPackage(packageMetadata *packages.Metadata) error {
 	log.Printf("removing package: %+v", packageMetadata)
 
 	if err := removePackage(packageMetadata); err != nil {
 		return err
 	}
 
-	fmt.Printf("uninstalled package '%s/%s'", packageMetadata.Package.Owner, packageMetadata.Package.Repo)
+	if err := metadata.Remove(keys.MetadataPath, *packageMetadata); err != nil {
+		return err
+	}
+
+	fmt.Printf("uninstalled package '%s/%s'\n", packageMetadata.Package.Owner, packageMetadata.Package.Repo)
 
 	return nil
 }
 
-func findPackageMetadata(pkg packages.Package, files []fs.FileInfo) (*packages.Metadata, string, error) {
-	for _, file := range files {
-		if file.Name() != pkg.Repo {
-			log.Printf("skipping file due to filename mismatch: %s", file.Name())
+// uninstallVersion removes inactive version of the package and keeps other versions.
+// The whole package is removed if the version is the only installed one.
+func uninstallVersion(m *packages.Metadata, version string) error {
+	fullName := fmt.Sprintf("%s/%s", m.Package.Owner, m.Package.Repo)
 
-			continue
-		}
+	v, ok := m.FindVersion(version)
+	if !ok {
+		printPackageNotInstalled(fmt.Sprintf("%s@%s", fullName, version))
 
-		path := filepath.Join(keys.MetadataPath, file.Name())
+		return nil
+	}
 
-		meta, err := metadata.Read(path)
-		if err != nil {
-			return nil, "", fmt.Errorf("error reading package metadata: %w", err)
-		}
+	if len(m.Installation.Versions) == 1 {
+		return uninstallPackage(m)
+	}
 
-		if meta.Package.Repo != pkg.Repo {
-			log.Printf("skipping file due to package metadata mismatch: %+v", meta.Package)
+	if m.IsActive(v) {
+		return fmt.Errorf("version %s of package '%s' is active, switch to another version with 'pmcli use' first",
+			v.Version.Value, fullName)
+	}
 
-			continue
-		}
+	log.Printf("removing version: %+v", v)
+
+	if err := os.RemoveAll(v.Package); err != nil {
+		return fmt.Errorf("error removing version folder: %w", err)
+	}
+
+	m.RemoveVersion(v)
 
-		return &meta, path, nil
+	if err := metadata.Save(keys.MetadataPath, *m, keys.MetadataPermissions); err != nil {
+		return fmt.Errorf("error saving package metadata: %w", err)
 	}
 
-	return nil, "", nil
+	fmt.Printf("uninstalled version %s of package '%s'\n", v.Version.Value, fullName)
+
+	return nil
 }
 
 func removePackage(packageMetadata *packages.Metadata) error {
@@ -120,15 +130,45 @@ func removePackage(packageMetadata *packages.Metadata) error {
 		return fmt.Errorf("error removing package folder: %w", err)
 	}
 
+	for _, v := range packageMetadata.Installation.Versions {
+		if err := os.RemoveAll(v.Package); err != nil {
+			return fmt.Errorf("error removing version folder: %w", err)
+		}
+	}
+
+	// Folders of the package and its owner are removed only if they are empty.
+	repoPath := filepath.Join(keys.PackagesPath, packageMetadata.Package.Owner, packageMetadata.Package.Repo)
+	_ = os.Remove(repoPath)
+	_ = os.Remove(filepath.Dir(repoPath))
+
 	for _, symlink := range packageMetadata.Installation.Symlinks {
-		if err := os.Remove(symlink); err != nil {
+		if err := os.Remove(symlink); err != nil && !errors.Is(err, os.ErrNotExist) {
 			return fmt.Errorf("error removing symlink: %w", err)
 		}
 	}
 
+	for _, file := range packageMetadata.Installation.Files {
+		if err := os.Remove(file); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("error removing file: %w", err)
+		}
+	}
+
 	return nil
 }
 
+// countInstalled returns number of installed packages with the repo name.
+func countInstalled(installed []packages.Metadata, repo string) int {
+	count := 0
+
+	for _, m := range installed {
+		if strings.EqualFold(m.Package.Repo, repo) {
+			count++
+		}
+	}
+
+	return count
+}
+
 func printPackageNotInstalled(name string) {
 	fmt.Printf("package '%s' isn't installed\n", name)
 	log.Printf("package isn't installed: %s", name)
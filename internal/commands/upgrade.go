@@ -0,0 +1,230 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/google/go-github/v39/github"
+	"github.com/iskorotkov/package-manager-cli/internal/keys"
+	"github.com/iskorotkov/package-manager-cli/internal/metadata"
+	"github.com/iskorotkov/package-manager-cli/pkg/assets"
+	"github.com/iskorotkov/package-manager-cli/pkg/packages"
+	"github.com/iskorotkov/package-manager-cli/pkg/xlog"
+	"github.com/spf13/cobra"
+)
+
+//nolint:gochecknoinits
+func init() {
+	upgradeCmd := wrapCommand(&cobra.Command{ //nolint:exhaustivestruct
+		Use:   "upgrade",
+		Short: "upgrade installed packages",
+		Args:  cobra.ArbitraryArgs,
+		RunE:  upgrade,
+	})
+
+	upgradeCmd.Flags().BoolP("all", "a", false, "upgrade all installed packages")
+	upgradeCmd.Flags().Bool("require-signature", false, "fail if release signature can't be verified with trusted keys")
+	addAssetFlags(upgradeCmd)
+
+	rootCmd.AddCommand(upgradeCmd)
+}
+
+func upgrade(cmd *cobra.Command, args []string) error {
+	all, err := cmd.Flags().GetBool("all")
+	if err != nil {
+		return fmt.Errorf("error reading flag value: %w", err)
+	}
+
+	requireSignature, err := cmd.Flags().GetBool("require-signature")
+	if err != nil {
+		return fmt.Errorf("error reading flag value: %w", err)
+	}
+
+	if all == (len(args) > 0) {
+		return fmt.Errorf("either package names or --all flag must be specified")
+	}
+
+	assetOpts, err := readAssetOptions(cmd)
+	if err != nil {
+		return err
+	}
+
+	installed, err := metadata.ReadAll(keys.MetadataPath)
+	if err != nil {
+		return err
+	}
+
+	selected, err := selectInstalled(installed, args)
+	if err != nil {
+		return err
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	opts := installOptions{
+		desktop:          false,
+		requireSignature: requireSignature,
+		asset:            assetOpts,
+		sha256:           "",
+	}
+
+	for _, m := range selected {
+		if err := upgradePackage(client, m, opts); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// selectInstalled returns metadata of packages with specified names.
+// It returns all installed packages if no names were specified.
+func selectInstalled(installed []packages.Metadata, names []string) ([]packages.Metadata, error) {
+	if len(names) == 0 {
+		return installed, nil
+	}
+
+	selected := make([]packages.Metadata, 0, len(names))
+
+	for _, name := range names {
+		pkg, err := packages.ParsePackage(name)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing package name: %w", err)
+		}
+
+		m, ok := findInstalled(installed, pkg, hasOwner(name))
+		if !ok {
+			printPackageNotInstalled(name)
+
+			continue
+		}
+
+		selected = append(selected, m)
+	}
+
+	return selected, nil
+}
+
+func findInstalled(installed []packages.Metadata, pkg packages.Package, exactRepo bool) (packages.Metadata, bool) {
+	for _, m := range installed {
+		if !strings.EqualFold(m.Package.Repo, pkg.Repo) {
+			continue
+		}
+
+		if exactRepo && !strings.EqualFold(m.Package.Owner, pkg.Owner) {
+			continue
+		}
+
+		return m, true
+	}
+
+	return packages.Metadata{}, false
+}
+
+func upgradePackage(client *github.Client, m packages.Metadata, opts installOptions) error {
+	fullName := fmt.Sprintf("%s/%s", m.Package.Owner, m.Package.Repo)
+
+	xlog.Push(fullName)
+	defer xlog.Pop()
+
+	if m.Package.Pinned {
+		log.Printf("package is pinned to version %s", m.Package.Version.Value)
+		fmt.Printf("package '%s' is pinned to version %s, skipping\n", fullName, m.Package.Version.Value)
+
+		return nil
+	}
+
+	repo, _, err := client.Repositories.Get(context.Background(), m.Package.Owner, m.Package.Repo)
+	if err != nil {
+		return fmt.Errorf("error getting repository '%s': %w", fullName, err)
+	}
+
+	release, err := findLatestRelease(
+		client,
+		m.Package.Owner,
+		m.Package.Repo,
+		m.Package.Constraint,
+		m.Package.Prerelease,
+	)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("installed version: %s, latest version: %s", m.Package.Version.Value, release.GetTagName())
+
+	if !isNewer(release, m.Package.Version) {
+		fmt.Printf("package '%s' is up to date (%s)\n", fullName, m.Package.Version.Value)
+
+		return nil
+	}
+
+	v, installed := m.FindVersion(release.GetTagName())
+
+	var (
+		asset   *github.ReleaseAsset
+		pattern string
+	)
+
+	// Asset is selected before transaction is started, as user may be asked to choose it
+	// and transaction intercepts interrupt signal.
+	if installed {
+		log.Printf("latest version is already installed: %+v", v)
+	} else {
+		// Asset pattern chosen on installation is used instead of platform detection.
+		if opts.asset.pattern == nil {
+			if err := opts.asset.setPattern(m.Package.AssetPattern); err != nil {
+				return err
+			}
+		}
+
+		asset, pattern, err = findAsset(release, opts.asset)
+		if err != nil {
+			return err
+		}
+	}
+
+	tx := newTransaction()
+	defer tx.rollback()
+
+	upgraded := m
+
+	if !installed {
+		v, err = installAsset(tx, client, assets.AssetData{
+			Repository: repo,
+			Release:    release,
+			Asset:      asset,
+			Pattern:    pattern,
+		}, opts)
+		if err != nil {
+			return err
+		}
+
+		upgraded.AddVersion(v)
+
+		if pattern != "" {
+			upgraded.Package.AssetPattern = pattern
+		}
+	}
+
+	// Previous version is kept side by side, so it's possible to switch back to it.
+	if err := activateVersion(tx, &upgraded, v, repo.GetDescription()); err != nil {
+		return err
+	}
+
+	log.Printf("saving metadata to: %s", keys.MetadataPath)
+
+	if err := metadata.Save(keys.MetadataPath, upgraded, keys.MetadataPermissions); err != nil {
+		return fmt.Errorf("error saving package metadata: %w", err)
+	}
+
+	tx.commit()
+
+	fmt.Printf("upgraded package '%s' from %s to %s\n", fullName, m.Package.Version.Value, release.GetTagName())
+
+	return nil
+}
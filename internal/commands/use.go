@@ -0,0 +1,159 @@
+package commands
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+
+	"github.com/iskorotkov/package-manager-cli/internal/keys"
+	"github.com/iskorotkov/package-manager-cli/internal/metadata"
+	"github.com/iskorotkov/package-manager-cli/pkg/appimages"
+	"github.com/iskorotkov/package-manager-cli/pkg/binaries"
+	"github.com/iskorotkov/package-manager-cli/pkg/packages"
+	"github.com/iskorotkov/package-manager-cli/pkg/xlog"
+	"github.com/spf13/cobra"
+)
+
+//nolint:gochecknoinits
+func init() {
+	useCmd := wrapCommand(&cobra.Command{ //nolint:exhaustivestruct
+		Use:   "use",
+		Short: "switch package to another installed version",
+		Args:  cobra.ExactArgs(1),
+		RunE:  use,
+		// Errors are caused by missing versions, not by invalid usage.
+		SilenceUsage: true,
+	})
+
+	rootCmd.AddCommand(useCmd)
+}
+
+func use(_ *cobra.Command, args []string) error {
+	packageName := args[0]
+
+	xlog.Push(packageName)
+	defer xlog.Pop()
+
+	log.Printf("package name: %s", packageName)
+
+	pkg, err := packages.ParsePackage(packageName)
+	if err != nil {
+		return fmt.Errorf("error parsing package name: %w", err)
+	}
+
+	if pkg.Version.Value == "" {
+		return fmt.Errorf("version must be specified (e. g. '%s@1.2.3')", packageName)
+	}
+
+	installed, err := metadata.ReadAll(keys.MetadataPath)
+	if err != nil {
+		return err
+	}
+
+	m, ok := findInstalled(installed, pkg, hasOwner(packageName))
+	if !ok {
+		printPackageNotInstalled(packageName)
+
+		return nil
+	}
+
+	fullName := fmt.Sprintf("%s/%s", m.Package.Owner, m.Package.Repo)
+
+	v, ok := m.FindVersion(pkg.Version.Value)
+	if !ok {
+		return fmt.Errorf("version %s of package '%s' isn't installed, installed versions: %s",
+			pkg.Version.Value, fullName, strings.Join(versionValues(m.Installation.Versions), ", "))
+	}
+
+	if m.IsActive(v) {
+		fmt.Printf("package '%s' already uses version %s\n", fullName, v.Version.Value)
+
+		return nil
+	}
+
+	tx := newTransaction()
+	defer tx.rollback()
+
+	previous := m.Package.Version.Value
+
+	if err := activateVersion(tx, &m, v, ""); err != nil {
+		return err
+	}
+
+	// Switching to a specific version is similar to installing it explicitly.
+	m.Package.Pinned = true
+
+	if err := metadata.Save(keys.MetadataPath, m, keys.MetadataPermissions); err != nil {
+		return fmt.Errorf("error saving package metadata: %w", err)
+	}
+
+	tx.commit()
+
+	fmt.Printf("switched package '%s' from %s to %s\n", fullName, previous, v.Version.Value)
+
+	return nil
+}
+
+// activateVersion points symlinks to binaries of the installed version and makes it active in metadata.
+// Symlinks of the previously active version are stashed, so they are restored on rollback.
+func activateVersion(tx *transaction, m *packages.Metadata, v packages.InstalledVersion, description string) error {
+	log.Printf("activating version: %+v", v)
+
+	for _, symlink := range m.Installation.Symlinks {
+		if err := tx.stash(symlink); err != nil {
+			return err
+		}
+	}
+
+	log.Printf("creating symlinks for binaries in %s at: %s", v.Binaries, keys.SymlinksPath)
+
+	symlinks, err := binaries.AddSymlinks(v.Binaries, keys.SymlinksPath, keys.SymlinksPermissions)
+
+	for _, symlink := range symlinks {
+		tx.created(symlink)
+	}
+
+	if err != nil {
+		return fmt.Errorf("error adding package to path: %w", err)
+	}
+
+	log.Printf("saved symlinks: %+v", symlinks)
+
+	m.Activate(v)
+	m.Installation.Symlinks = symlinks
+
+	// Desktop entry points to the symlink, so it doesn't need to be updated when switching versions.
+	if v.AppImage && m.Installation.Desktop && len(m.Installation.Files) == 0 {
+		m.Installation.Files = integrateAppImage(appImagePath(v, m.Package.Repo, symlinks), description)
+
+		for _, file := range m.Installation.Files {
+			tx.created(file)
+		}
+	}
+
+	return tx.check()
+}
+
+// appImagePath returns symlink to AppImage if it was created. Otherwise, it returns AppImage in the version folder.
+func appImagePath(v packages.InstalledVersion, repo string, symlinks []string) string {
+	name := appimages.BinaryName(repo)
+
+	for _, symlink := range symlinks {
+		if filepath.Base(symlink) == name {
+			return symlink
+		}
+	}
+
+	return filepath.Join(v.Package, name)
+}
+
+func versionValues(versions []packages.InstalledVersion) []string {
+	values := make([]string, 0, len(versions))
+
+	for _, v := range versions {
+		values = append(values, v.Version.Value)
+	}
+
+	return values
+}
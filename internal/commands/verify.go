@@ -0,0 +1,215 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/google/go-github/v39/github"
+	"github.com/iskorotkov/package-manager-cli/internal/keys"
+	"github.com/iskorotkov/package-manager-cli/pkg/assets"
+	"github.com/iskorotkov/package-manager-cli/pkg/checksums"
+	"github.com/iskorotkov/package-manager-cli/pkg/signatures"
+	"github.com/iskorotkov/package-manager-cli/pkg/xlog"
+)
+
+const (
+	// maxChecksumFileSize limits size of checksum files, as they are read to memory.
+	maxChecksumFileSize = 1 << 20
+	// maxSignatureFileSize limits size of signature files, as they are read to memory.
+	maxSignatureFileSize = 1 << 20
+)
+
+// checksumResult is a result of asset checksum verification.
+type checksumResult struct {
+	digest   string
+	verified bool
+	// source is a checksum file that was used for verification.
+	source *github.ReleaseAsset
+	// content is a content of the checksum file. Its signature can be used to verify the asset.
+	content []byte
+}
+
+// signedFile is a file which signature can be verified.
+type signedFile struct {
+	name string
+	open func() (io.ReadCloser, error)
+}
+
+// verifyChecksum calculates digest of the downloaded asset and compares it with digests
+// from checksum files published in the same release. Mismatched digest is returned as error.
+func verifyChecksum(client *github.Client, asset assets.AssetData, path string) (checksumResult, error) {
+	xlog.Push("checksum")
+	defer xlog.Pop()
+
+	digest, err := checksums.File(path)
+	if err != nil {
+		return checksumResult{}, fmt.Errorf("error calculating asset digest: %w", err)
+	}
+
+	log.Printf("asset digest: %s", digest)
+
+	downloader := assets.NewDownloader(client)
+
+	for _, checksumAsset := range checksums.FindAssets(asset.Release.Assets, asset.Asset.GetName()) {
+		log.Printf("checking digest in: %s", checksumAsset.GetName())
+
+		content, err := downloader.Read(context.Background(), asset.Repository, checksumAsset, maxChecksumFileSize)
+		if err != nil {
+			return checksumResult{}, fmt.Errorf("error downloading checksum file '%s': %w", checksumAsset.GetName(), err)
+		}
+
+		expected, err := checksums.Parse(content, asset.Asset.GetName())
+		if errors.Is(err, checksums.ErrNotFound) {
+			log.Printf("checksum file doesn't contain asset digest")
+
+			continue
+		} else if err != nil {
+			return checksumResult{}, fmt.Errorf("error parsing checksum file '%s': %w", checksumAsset.GetName(), err)
+		}
+
+		if err := checksums.Verify(digest, expected); err != nil {
+			return checksumResult{}, fmt.Errorf("error verifying asset '%s' using '%s': %w",
+				asset.Asset.GetName(), checksumAsset.GetName(), err)
+		}
+
+		log.Printf("digest verified using: %s", checksumAsset.GetName())
+
+		return checksumResult{
+			digest:   digest,
+			verified: true,
+			source:   checksumAsset,
+			content:  content,
+		}, nil
+	}
+
+	log.Printf("no checksum files found for asset")
+
+	return checksumResult{digest: digest}, nil //nolint:exhaustivestruct
+}
+
+// verifySignature verifies signature of the asset or signature of the checksum file that was used to verify it
+// using keys trusted for the repo owner. It returns type of the verified signature
+// or empty string if signature wasn't verified.
+// Errors are returned only if signature is required, otherwise they are reported as warnings.
+func verifySignature(
+	client *github.Client,
+	asset assets.AssetData,
+	path string,
+	checksum checksumResult,
+	required bool,
+) (string, error) {
+	xlog.Push("signature")
+	defer xlog.Pop()
+
+	owner := asset.Repository.GetOwner().GetLogin()
+
+	keyring, err := signatures.LoadKeyring(keys.KeyringPath)
+	if err != nil {
+		return "", err
+	}
+
+	trusted := keyring.Keys(owner)
+	if len(trusted) == 0 {
+		log.Printf("no trusted keys for owner: %s", owner)
+
+		return "", signatureError(required, fmt.Errorf("no trusted keys for '%s', use 'trust' command to add them", owner))
+	}
+
+	files := []signedFile{{
+		name: asset.Asset.GetName(),
+		open: func() (io.ReadCloser, error) {
+			return os.Open(path)
+		},
+	}}
+
+	if checksum.verified {
+		files = append(files, signedFile{
+			name: checksum.source.GetName(),
+			open: func() (io.ReadCloser, error) {
+				return io.NopCloser(bytes.NewReader(checksum.content)), nil
+			},
+		})
+	}
+
+	signatureType, err := verifySignedFiles(client, asset, files, trusted)
+	if err != nil {
+		return "", signatureError(required, err)
+	}
+
+	return signatureType, nil
+}
+
+func verifySignedFiles(
+	client *github.Client,
+	asset assets.AssetData,
+	files []signedFile,
+	trusted []signatures.Key,
+) (string, error) {
+	downloader := assets.NewDownloader(client)
+
+	lastErr := fmt.Errorf("no signatures found for '%s'", asset.Asset.GetName())
+
+	for _, file := range files {
+		for _, candidate := range signatures.FindSignatures(asset.Release.Assets, file.name) {
+			log.Printf("checking signature: %s", candidate.Asset.GetName())
+
+			signature, err := downloader.Read(context.Background(), asset.Repository, candidate.Asset, maxSignatureFileSize)
+			if err != nil {
+				return "", fmt.Errorf("error downloading signature '%s': %w", candidate.Asset.GetName(), err)
+			}
+
+			signatureType, err := verifyFile(file, candidate, signature, trusted)
+			if err == nil {
+				log.Printf("signature verified: %s (%s)", candidate.Asset.GetName(), signatureType)
+
+				return string(signatureType), nil
+			}
+
+			log.Printf("error verifying signature '%s': %v", candidate.Asset.GetName(), err)
+
+			lastErr = fmt.Errorf("error verifying signature '%s': %w", candidate.Asset.GetName(), err)
+		}
+	}
+
+	return "", lastErr
+}
+
+func verifyFile(
+	file signedFile,
+	candidate signatures.Candidate,
+	signature []byte,
+	trusted []signatures.Key,
+) (signatures.Type, error) {
+	rc, err := file.open()
+	if err != nil {
+		return signatures.TypeUnknown, fmt.Errorf("error opening signed file: %w", err)
+	}
+
+	defer func(rc io.ReadCloser) {
+		_ = rc.Close()
+	}(rc)
+
+	return signatures.Verify(candidate.Type, signature, rc, trusted)
+}
+
+// signatureError returns error if signature is required and prints warning otherwise.
+func signatureError(required bool, err error) error {
+	if required {
+		return fmt.Errorf("signature verification failed: %w", err)
+	}
+
+	log.Printf("signature wasn't verified: %v", err)
+
+	if !errors.Is(err, signatures.ErrInvalidSignature) {
+		return nil
+	}
+
+	fmt.Printf("warning: signature wasn't verified: %v\n", err)
+
+	return nil
+}
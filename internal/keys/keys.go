@@ -8,15 +8,25 @@ import (
 
 //nolint:gochecknoglobals,gomnd,gofumpt
 var (
-	DownloadsPath = env.Get("PM_DOWNLOADS_PATH", "~/.local/share/package-manager/downloads")
-	PackagesPath  = env.Get("PM_PACKAGES_PATH", "~/.local/share/package-manager/packages")
-	MetadataPath  = env.Get("PM_METADATA_PATH", "~/.local/share/package-manager/metadata")
-	LogsPath      = env.Get("PM_LOGS_PATH", "~/.local/share/package-manager/logs")
-	SymlinksPath  = env.Get("PM_SYMLINKS_PATH", "~/.local/bin")
+	DownloadsPath = env.GetPath("PM_DOWNLOADS_PATH", "~/.local/share/package-manager/downloads")
+	PackagesPath  = env.GetPath("PM_PACKAGES_PATH", "~/.local/share/package-manager/packages")
+	MetadataPath  = env.GetPath("PM_METADATA_PATH", "~/.local/share/package-manager/metadata")
+	LogsPath      = env.GetPath("PM_LOGS_PATH", "~/.local/share/package-manager/logs")
+	SymlinksPath  = env.GetPath("PM_SYMLINKS_PATH", "~/.local/bin")
+	DataPath      = env.GetPath("XDG_DATA_HOME", "~/.local/share")
+	KeyringPath   = env.GetPath("PM_KEYRING_PATH", "~/.local/share/package-manager/keyring.json")
+	TokenPath     = env.GetPath("PM_TOKEN_PATH", "~/.local/share/package-manager/token")
+
+	// GitHubAPIURL and GitHubUploadURL are set to use GitHub Enterprise Server instead of github.com.
+	GitHubAPIURL    = env.Get("PM_GITHUB_API_URL", "")
+	GitHubUploadURL = env.Get("PM_GITHUB_UPLOAD_URL", "")
 
 	DownloadsPermissions = os.FileMode(env.GetInt("PM_DOWNLOADS_PERMISSIONS", 0744))
 	PackagesPermissions  = os.FileMode(env.GetInt("PM_PACKAGES_PERMISSIONS", 0744))
 	MetadataPermissions  = os.FileMode(env.GetInt("PM_METADATA_PERMISSIONS", 0744))
 	LogsPermissions      = os.FileMode(env.GetInt("PM_LOGS_PERMISSIONS", 0744))
 	SymlinksPermissions  = os.FileMode(env.GetInt("PM_SYMLINKS_PERMISSIONS", 0744))
+	DataPermissions      = os.FileMode(env.GetInt("PM_DATA_PERMISSIONS", 0744))
+	KeyringPermissions   = os.FileMode(env.GetInt("PM_KEYRING_PERMISSIONS", 0700))
+	LockfilePermissions  = os.FileMode(env.GetInt("PM_LOCKFILE_PERMISSIONS", 0644))
 )
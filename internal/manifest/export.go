@@ -0,0 +1,68 @@
+package manifest
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Export is a portable list of installed packages.
+type Export struct {
+	Packages []Exported `yaml:"packages"`
+}
+
+// Exported is an installed package with its version requirements.
+type Exported struct {
+	// Repo is owner and name of the repo (e. g. "derailed/k9s").
+	Repo string `yaml:"repo"`
+	// Version is a tag of the installed release.
+	Version string `yaml:"version"`
+	// Asset is a name of the installed asset.
+	Asset string `yaml:"asset,omitempty"`
+	// AssetPattern selects asset on upgrades instead of platform detection.
+	AssetPattern string `yaml:"assetPattern,omitempty"`
+	Pinned       bool   `yaml:"pinned,omitempty"`
+	Constraint   string `yaml:"constraint,omitempty"`
+	Desktop      bool   `yaml:"desktop,omitempty"`
+	Prerelease   bool   `yaml:"prerelease,omitempty"`
+}
+
+// ReadExport reads file with exported packages.
+func ReadExport(src string) (Export, error) {
+	b, err := os.ReadFile(src)
+	if err != nil {
+		return Export{}, fmt.Errorf("error reading export file: %w", err)
+	}
+
+	var e Export
+	if err := yaml.Unmarshal(b, &e); err != nil {
+		return Export{}, fmt.Errorf("error parsing export file '%s': %w", src, err)
+	}
+
+	for i, p := range e.Packages {
+		if parts := strings.Split(p.Repo, "/"); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return Export{}, fmt.Errorf("package #%d: repo must be in format 'owner/repo', got '%s'", i+1, p.Repo)
+		}
+	}
+
+	return e, nil
+}
+
+// WriteExport writes exported packages in YAML format.
+func WriteExport(w io.Writer, e Export) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2) //nolint:gomnd
+
+	if err := encoder.Encode(&e); err != nil {
+		return fmt.Errorf("error writing exported packages: %w", err)
+	}
+
+	if err := encoder.Close(); err != nil {
+		return fmt.Errorf("error writing exported packages: %w", err)
+	}
+
+	return nil
+}
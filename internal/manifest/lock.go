@@ -0,0 +1,91 @@
+package manifest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const lockHeader = "# This file is generated by 'pmcli lock'. Don't edit it manually.\n"
+
+// Lockfile contains exact releases and assets resolved for packages listed in manifest.
+type Lockfile struct {
+	Packages []Locked `yaml:"packages"`
+}
+
+// Locked is a package with resolved release and asset.
+type Locked struct {
+	// Repo, Version, Pattern and Prerelease are copied from manifest, so outdated lockfile can be detected.
+	Repo       string `yaml:"repo"`
+	Version    string `yaml:"version,omitempty"`
+	Pattern    string `yaml:"pattern,omitempty"`
+	Prerelease bool   `yaml:"prerelease,omitempty"`
+	// Tag is a tag of the resolved release.
+	Tag string `yaml:"tag"`
+	// Asset is a name of the resolved asset.
+	Asset  string `yaml:"asset"`
+	URL    string `yaml:"url"`
+	SHA256 string `yaml:"sha256"`
+}
+
+// LockPath returns path of the lockfile for the manifest (e. g. "pmcli.yaml" -> "pmcli.lock").
+func LockPath(manifestPath string) string {
+	return strings.TrimSuffix(manifestPath, filepath.Ext(manifestPath)) + ".lock"
+}
+
+// Matches reports whether package was locked with the same requirements as listed in manifest.
+func (l Locked) Matches(p Package) bool {
+	return strings.EqualFold(l.Repo, p.Repo) && l.Version == p.Version && l.Pattern == p.Asset &&
+		l.Prerelease == p.Prerelease
+}
+
+// Find returns locked package with the same repo.
+func (l Lockfile) Find(repo string) (Locked, bool) {
+	for _, p := range l.Packages {
+		if strings.EqualFold(p.Repo, repo) {
+			return p, true
+		}
+	}
+
+	return Locked{}, false
+}
+
+// ReadLock reads lockfile.
+func ReadLock(src string) (Lockfile, error) {
+	b, err := os.ReadFile(src)
+	if err != nil {
+		return Lockfile{}, fmt.Errorf("error reading lockfile: %w", err)
+	}
+
+	var l Lockfile
+	if err := yaml.Unmarshal(b, &l); err != nil {
+		return Lockfile{}, fmt.Errorf("error parsing lockfile '%s': %w", src, err)
+	}
+
+	return l, nil
+}
+
+// WriteLock writes lockfile. Lockfile is written to a temp file first and then renamed.
+func WriteLock(dest string, l Lockfile, permissions os.FileMode) error {
+	b, err := yaml.Marshal(&l)
+	if err != nil {
+		return fmt.Errorf("error marshaling lockfile: %w", err)
+	}
+
+	tempPath := dest + ".tmp"
+
+	if err := os.WriteFile(tempPath, append([]byte(lockHeader), b...), permissions); err != nil {
+		return fmt.Errorf("error writing lockfile '%s': %w", tempPath, err)
+	}
+
+	if err := os.Rename(tempPath, dest); err != nil {
+		_ = os.Remove(tempPath)
+
+		return fmt.Errorf("error replacing lockfile '%s': %w", dest, err)
+	}
+
+	return nil
+}
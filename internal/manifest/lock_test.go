@@ -0,0 +1,111 @@
+package manifest
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLockedMatches(t *testing.T) {
+	t.Parallel()
+
+	locked := Locked{
+		Repo:       "derailed/k9s",
+		Version:    "^0.25",
+		Pattern:    "*Linux_x86_64*",
+		Prerelease: false,
+		Tag:        "v0.25.18",
+		Asset:      "k9s_Linux_x86_64.tar.gz",
+		URL:        "https://github.com/derailed/k9s/releases/download/v0.25.18/k9s_Linux_x86_64.tar.gz",
+		SHA256:     "abc",
+	}
+
+	tests := []struct {
+		name string
+		pkg  Package
+		want bool
+	}{
+		{
+			name: "same requirements",
+			pkg:  Package{Repo: "derailed/k9s", Version: "^0.25", Asset: "*Linux_x86_64*"},
+			want: true,
+		},
+		{name: "repo case", pkg: Package{Repo: "Derailed/K9s", Version: "^0.25", Asset: "*Linux_x86_64*"}, want: true},
+		{name: "other repo", pkg: Package{Repo: "other/k9s", Version: "^0.25", Asset: "*Linux_x86_64*"}},
+		{name: "other version", pkg: Package{Repo: "derailed/k9s", Version: "^0.26", Asset: "*Linux_x86_64*"}},
+		{name: "other asset", pkg: Package{Repo: "derailed/k9s", Version: "^0.25", Asset: "*arm64*"}},
+		{name: "asset removed", pkg: Package{Repo: "derailed/k9s", Version: "^0.25"}},
+		{
+			name: "prerelease allowed",
+			pkg:  Package{Repo: "derailed/k9s", Version: "^0.25", Asset: "*Linux_x86_64*", Prerelease: true},
+		},
+		{
+			name: "desktop integration doesn't matter",
+			pkg:  Package{Repo: "derailed/k9s", Version: "^0.25", Asset: "*Linux_x86_64*", Desktop: true},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := locked.Matches(tt.pkg); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLockfileFind(t *testing.T) {
+	t.Parallel()
+
+	l := Lockfile{Packages: []Locked{
+		{Repo: "derailed/k9s", Tag: "v0.25.18"},     //nolint:exhaustivestruct
+		{Repo: "BurntSushi/ripgrep", Tag: "13.0.0"}, //nolint:exhaustivestruct
+	}}
+
+	if got, ok := l.Find("burntsushi/ripgrep"); !ok || got.Tag != "13.0.0" {
+		t.Errorf("Find() = %+v, %v, want ripgrep", got, ok)
+	}
+
+	if _, ok := l.Find("sharkdp/bat"); ok {
+		t.Errorf("Find() found package that isn't locked")
+	}
+}
+
+func TestLockReadWrite(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), LockPath(DefaultPath))
+
+	l := Lockfile{Packages: []Locked{{
+		Repo:       "derailed/k9s",
+		Version:    "",
+		Pattern:    "*Linux_x86_64*",
+		Prerelease: true,
+		Tag:        "v0.25.18",
+		Asset:      "k9s_Linux_x86_64.tar.gz",
+		URL:        "https://example.com/k9s_Linux_x86_64.tar.gz",
+		SHA256:     "abc",
+	}}}
+
+	if err := WriteLock(path, l, 0o600); err != nil {
+		t.Fatalf("WriteLock() error = %v", err)
+	}
+
+	got, err := ReadLock(path)
+	if err != nil {
+		t.Fatalf("ReadLock() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(got, l) {
+		t.Errorf("ReadLock() = %+v, want %+v", got, l)
+	}
+
+	if LockPath("config/pmcli.yaml") != "config/pmcli.lock" {
+		t.Errorf("LockPath() = %s", LockPath("config/pmcli.yaml"))
+	}
+}
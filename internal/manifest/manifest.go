@@ -0,0 +1,86 @@
+package manifest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultPath is a path of manifest file used when it isn't specified explicitly.
+const DefaultPath = "pmcli.yaml"
+
+// Manifest is a list of packages that must be installed.
+type Manifest struct {
+	Packages []Package `yaml:"packages"`
+}
+
+// Package is a package listed in manifest.
+type Package struct {
+	// Repo is owner and name of the repo (e. g. "derailed/k9s").
+	Repo string `yaml:"repo"`
+	// Version is an exact version (e. g. "v0.25.18") or a constraint (e. g. "^0.25"). Latest release is used if empty.
+	Version string `yaml:"version,omitempty"`
+	// Asset is a glob or regular expression used to select release asset instead of platform detection.
+	Asset string `yaml:"asset,omitempty"`
+	// Desktop enables desktop integration for AppImages.
+	Desktop bool `yaml:"desktop,omitempty"`
+	// Prerelease allows installing prereleases.
+	Prerelease bool `yaml:"prerelease,omitempty"`
+}
+
+// Name returns package name with version that can be parsed with packages.ParsePackage (e. g. "derailed/k9s@^0.25").
+func (p Package) Name() string {
+	if p.Version == "" {
+		return p.Repo
+	}
+
+	return p.Repo + "@" + p.Version
+}
+
+// Read reads manifest file and validates listed packages.
+func Read(src string) (Manifest, error) {
+	b, err := os.ReadFile(src)
+	if err != nil {
+		return Manifest{}, fmt.Errorf("error reading manifest file: %w", err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(b))
+	decoder.KnownFields(true)
+
+	// Empty manifest file is valid and doesn't list any packages.
+	var m Manifest
+	if err := decoder.Decode(&m); err != nil && !errors.Is(err, io.EOF) {
+		return Manifest{}, fmt.Errorf("error parsing manifest file '%s': %w", src, err)
+	}
+
+	if err := m.validate(); err != nil {
+		return Manifest{}, fmt.Errorf("invalid manifest file '%s': %w", src, err)
+	}
+
+	return m, nil
+}
+
+func (m Manifest) validate() error {
+	seen := make(map[string]bool, len(m.Packages))
+
+	for i, p := range m.Packages {
+		parts := strings.Split(p.Repo, "/")
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return fmt.Errorf("package #%d: repo must be in format 'owner/repo', got '%s'", i+1, p.Repo)
+		}
+
+		key := strings.ToLower(p.Repo)
+		if seen[key] {
+			return fmt.Errorf("package #%d: repo '%s' is listed more than once", i+1, p.Repo)
+		}
+
+		seen[key] = true
+	}
+
+	return nil
+}
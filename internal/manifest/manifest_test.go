@@ -0,0 +1,87 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestRead(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content string
+		want    []Package
+		wantErr bool
+	}{
+		{
+			name: "packages",
+			content: `packages:
+  - repo: derailed/k9s
+    version: ^0.25
+  - repo: BurntSushi/ripgrep
+    asset: "*musl*"
+    prerelease: true
+  - repo: neovim/neovim
+    version: nightly
+    desktop: true
+`,
+			want: []Package{
+				{Repo: "derailed/k9s", Version: "^0.25"},
+				{Repo: "BurntSushi/ripgrep", Asset: "*musl*", Prerelease: true},
+				{Repo: "neovim/neovim", Version: "nightly", Desktop: true},
+			},
+		},
+		{name: "empty file", content: ""},
+		{name: "no packages", content: "packages: []\n", want: []Package{}},
+		{name: "repo without owner", content: "packages:\n  - repo: k9s\n", wantErr: true},
+		{name: "empty owner", content: "packages:\n  - repo: /k9s\n", wantErr: true},
+		{name: "empty repo", content: "packages:\n  - repo: derailed/\n", wantErr: true},
+		{name: "too many parts", content: "packages:\n  - repo: github.com/derailed/k9s\n", wantErr: true},
+		{
+			name:    "duplicate repo",
+			content: "packages:\n  - repo: derailed/k9s\n  - repo: Derailed/K9s\n",
+			wantErr: true,
+		},
+		{name: "unknown package field", content: "packages:\n  - repo: derailed/k9s\n    tag: v1\n", wantErr: true},
+		{name: "unknown top-level field", content: "version: 1\npackages: []\n", wantErr: true},
+		{name: "invalid yaml", content: "packages: [", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), DefaultPath)
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := Read(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Read() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && !reflect.DeepEqual(got.Packages, tt.want) {
+				t.Errorf("Read() = %+v, want %+v", got.Packages, tt.want)
+			}
+		})
+	}
+}
+
+func TestPackageName(t *testing.T) {
+	t.Parallel()
+
+	if got := (Package{Repo: "derailed/k9s"}).Name(); got != "derailed/k9s" { //nolint:exhaustivestruct
+		t.Errorf("Name() = %s, want derailed/k9s", got)
+	}
+
+	withVersion := Package{Repo: "derailed/k9s", Version: "^0.25"} //nolint:exhaustivestruct
+	if got := withVersion.Name(); got != "derailed/k9s@^0.25" {
+		t.Errorf("Name() = %s, want derailed/k9s@^0.25", got)
+	}
+}
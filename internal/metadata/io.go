@@ -2,32 +2,56 @@ package metadata
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 
+	"github.com/iskorotkov/package-manager-cli/pkg/appimages"
+	"github.com/iskorotkov/package-manager-cli/pkg/archives"
 	"github.com/iskorotkov/package-manager-cli/pkg/assets"
 	"github.com/iskorotkov/package-manager-cli/pkg/packages"
 )
 
-func Save(src, dest string, asset assets.AssetData, symlinks []string, permissions os.FileMode) error {
-	if err := os.MkdirAll(dest, permissions); err != nil {
-		return fmt.Errorf("error creating folder '%s' for metadata: %w", dest, err)
-	}
+// legacyExt is added to legacy metadata files while they are migrated.
+const legacyExt = ".legacy"
 
-	m := packages.Metadata{
-		Package: packages.Package{
+// New returns metadata of the package without installed versions.
+func New(asset assets.AssetData) packages.Metadata {
+	return packages.Metadata{ //nolint:exhaustivestruct
+		Package: packages.Package{ //nolint:exhaustivestruct
 			Owner: asset.Repository.GetOwner().GetLogin(),
 			Repo:  asset.Repository.GetName(),
-			Version: packages.Version{ //nolint:exhaustivestruct
-				// TODO: Parse version values.
-				Value: asset.Release.GetTagName(),
-			},
 		},
-		Installation: packages.Installation{
-			Package:  src,
-			Symlinks: symlinks,
+	}
+}
+
+// NewVersion returns installed version of the package stored in src folder.
+func NewVersion(src string, binaries string, asset assets.AssetData) packages.InstalledVersion {
+	// Tags that can't be parsed are saved as is.
+	version, _ := packages.ParseVersion(asset.Release.GetTagName())
+
+	return packages.InstalledVersion{ //nolint:exhaustivestruct
+		Version: version,
+		Asset: packages.Asset{ //nolint:exhaustivestruct
+			Name: asset.Asset.GetName(),
+			URL:  asset.Asset.GetBrowserDownloadURL(),
 		},
+		Package:  src,
+		Binaries: binaries,
+	}
+}
+
+// Path returns path of metadata file of the package ("<dest>/<owner>/<repo>").
+func Path(dest string, m packages.Metadata) string {
+	return filepath.Join(dest, m.Package.Owner, m.Package.Repo)
+}
+
+func Save(dest string, m packages.Metadata, permissions os.FileMode) error {
+	metadataPath := Path(dest, m)
+
+	if err := os.MkdirAll(filepath.Dir(metadataPath), permissions); err != nil {
+		return fmt.Errorf("error creating folder '%s' for metadata: %w", filepath.Dir(metadataPath), err)
 	}
 
 	b, err := json.MarshalIndent(&m, "", "  ")
@@ -35,15 +59,35 @@ func Save(src, dest string, asset assets.AssetData, symlinks []string, permissio
 		return fmt.Errorf("error marshaling package metadata: %w", err)
 	}
 
-	metadataPath := filepath.Join(dest, asset.Repository.GetName())
+	// Metadata is written to a temp file first and then renamed, so the file is never left partially written.
+	tempPath := metadataPath + ".tmp"
+
+	if err := os.WriteFile(tempPath, b, permissions); err != nil {
+		return fmt.Errorf("error writing metadata file '%s': %w", tempPath, err)
+	}
+
+	if err := os.Rename(tempPath, metadataPath); err != nil {
+		_ = os.Remove(tempPath)
 
-	if err := os.WriteFile(metadataPath, b, permissions); err != nil {
-		return fmt.Errorf("error writing metadata file '%s': %w", metadataPath, err)
+		return fmt.Errorf("error replacing metadata file '%s': %w", metadataPath, err)
 	}
 
 	return nil
 }
 
+// Remove removes metadata file of the package and its owner folder if it's empty.
+func Remove(dest string, m packages.Metadata) error {
+	metadataPath := Path(dest, m)
+
+	if err := os.Remove(metadataPath); err != nil {
+		return fmt.Errorf("error removing metadata file '%s': %w", metadataPath, err)
+	}
+
+	_ = os.Remove(filepath.Dir(metadataPath))
+
+	return nil
+}
+
 func Read(src string) (packages.Metadata, error) {
 	b, err := os.ReadFile(src)
 	if err != nil {
@@ -55,5 +99,136 @@ func Read(src string) (packages.Metadata, error) {
 		return packages.Metadata{}, fmt.Errorf("error unmarshaling package metadata")
 	}
 
+	// Packages installed before side by side versions were supported have only one version.
+	if len(m.Installation.Versions) == 0 && m.Installation.Package != "" {
+		m.Installation.Versions = []packages.InstalledVersion{legacyVersion(m)}
+	}
+
 	return m, nil
 }
+
+func legacyVersion(m packages.Metadata) packages.InstalledVersion {
+	binaries := m.Installation.Package
+
+	// Binaries of deb and rpm packages are stored in "usr/bin" folder.
+	if info, err := os.Stat(filepath.Join(binaries, "usr", "bin")); err == nil && info.IsDir() {
+		binaries = filepath.Join(binaries, "usr")
+	}
+
+	return packages.InstalledVersion{
+		Version:  m.Package.Version,
+		Asset:    m.Asset,
+		Package:  m.Installation.Package,
+		Binaries: binaries,
+		AppImage: isAppImage(filepath.Join(m.Installation.Package, appimages.BinaryName(m.Package.Repo))),
+	}
+}
+
+// isAppImage reports whether the file is an AppImage. Missing files and files of unknown format aren't AppImages.
+func isAppImage(src string) bool {
+	format, err := archives.Detect(src)
+
+	return err == nil && format.IsAppImage()
+}
+
+// ReadAll reads metadata of all installed packages.
+// It returns empty slice if metadata folder doesn't exist yet.
+func ReadAll(src string) ([]packages.Metadata, error) {
+	if err := migrateLegacy(src); err != nil {
+		return nil, err
+	}
+
+	owners, err := os.ReadDir(src)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error opening metadata folder: %w", err)
+	}
+
+	var result []packages.Metadata
+
+	for _, owner := range owners {
+		if !owner.IsDir() {
+			continue
+		}
+
+		entries, err := os.ReadDir(filepath.Join(src, owner.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("error opening metadata folder of owner '%s': %w", owner.Name(), err)
+		}
+
+		for _, entry := range entries {
+			if entry.IsDir() || filepath.Ext(entry.Name()) == ".tmp" {
+				continue
+			}
+
+			m, err := Read(filepath.Join(src, owner.Name(), entry.Name()))
+			if err != nil {
+				return nil, fmt.Errorf("error reading metadata of package '%s/%s': %w", owner.Name(), entry.Name(), err)
+			}
+
+			result = append(result, m)
+		}
+	}
+
+	return result, nil
+}
+
+// migrateLegacy moves metadata files saved as "<repo>" to "<owner>/<repo>",
+// so packages with the same name and different owners don't overwrite metadata of each other.
+func migrateLegacy(dest string) error {
+	entries, err := os.ReadDir(dest)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("error opening metadata folder: %w", err)
+	}
+
+	info, err := os.Stat(dest)
+	if err != nil {
+		return fmt.Errorf("error getting permissions of metadata folder: %w", err)
+	}
+
+	var legacy []string
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) == ".tmp" {
+			continue
+		}
+
+		path := filepath.Join(dest, entry.Name())
+
+		// Legacy files are renamed first, as they may have the same name as owner folders created below.
+		// Owner names can't contain dots, so renamed files never clash with them.
+		if filepath.Ext(path) != legacyExt {
+			if err := os.Rename(path, path+legacyExt); err != nil {
+				return fmt.Errorf("error renaming legacy metadata file '%s': %w", path, err)
+			}
+
+			path += legacyExt
+		}
+
+		legacy = append(legacy, path)
+	}
+
+	for _, path := range legacy {
+		m, err := Read(path)
+		if err != nil {
+			return fmt.Errorf("error reading legacy metadata file '%s': %w", path, err)
+		}
+
+		if m.Package.Owner == "" || m.Package.Repo == "" {
+			return fmt.Errorf("legacy metadata file '%s' doesn't contain owner and repo", path)
+		}
+
+		if err := Save(dest, m, info.Mode().Perm()); err != nil {
+			return err
+		}
+
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("error removing legacy metadata file '%s': %w", path, err)
+		}
+	}
+
+	return nil
+}
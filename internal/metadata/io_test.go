@@ -0,0 +1,117 @@
+package metadata_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/iskorotkov/package-manager-cli/internal/metadata"
+	"github.com/iskorotkov/package-manager-cli/pkg/packages"
+)
+
+func TestReadAllMigratesLegacyFiles(t *testing.T) {
+	t.Parallel()
+
+	dest := t.TempDir()
+
+	// Legacy file of derailed/k9s has the same name as owner folder of k9s/k9s.
+	legacy := `{"package":{"owner":"derailed","repo":"k9s","version":{"value":"v1.0.0"}},"installation":{}}`
+	if err := os.WriteFile(filepath.Join(dest, "k9s"), []byte(legacy), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	other := packages.Metadata{ //nolint:exhaustivestruct
+		Package: packages.Package{Owner: "k9s", Repo: "k9s"}, //nolint:exhaustivestruct
+	}
+
+	installed, err := metadata.ReadAll(dest)
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+
+	if len(installed) != 1 {
+		t.Fatalf("ReadAll() returned %d packages, want 1", len(installed))
+	}
+
+	if err := metadata.Save(dest, other, 0o700); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	installed, err = metadata.ReadAll(dest)
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+
+	if len(installed) != 2 {
+		t.Fatalf("ReadAll() returned %d packages, want 2", len(installed))
+	}
+
+	if _, err := os.Stat(filepath.Join(dest, "derailed", "k9s")); err != nil {
+		t.Errorf("legacy metadata wasn't migrated: %v", err)
+	}
+}
+
+func TestReadDetectsLegacyAppImage(t *testing.T) {
+	t.Parallel()
+
+	appImageHeader := []byte{0x7f, 'E', 'L', 'F', 2, 1, 1, 0, 'A', 'I', 0x02, 0, 0, 0, 0, 0}
+	elfHeader := []byte{0x7f, 'E', 'L', 'F', 2, 1, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+
+	tests := []struct {
+		name    string
+		repo    string
+		file    string
+		header  []byte
+		desktop bool
+		want    bool
+	}{
+		{name: "appimage", repo: "Obsidian", file: "obsidian", header: appImageHeader, desktop: false, want: true},
+		{
+			name: "appimage with desktop", repo: "Obsidian", file: "obsidian",
+			header: appImageHeader, desktop: true, want: true,
+		},
+		{name: "elf with desktop", repo: "k9s", file: "k9s", header: elfHeader, desktop: true, want: false},
+		{name: "missing file with desktop", repo: "k9s", file: "other", header: elfHeader, desktop: true, want: false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+
+			packagePath := filepath.Join(dir, "package")
+			if err := os.MkdirAll(packagePath, 0o700); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := os.WriteFile(filepath.Join(packagePath, tt.file), tt.header, 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			legacy := fmt.Sprintf(`{"package":{"owner":"owner","repo":%q,"version":{"value":"v1.0.0"}},`+
+				`"installation":{"package":%q,"desktop":%t}}`, tt.repo, packagePath, tt.desktop)
+
+			metadataPath := filepath.Join(dir, "metadata")
+			if err := os.WriteFile(metadataPath, []byte(legacy), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			m, err := metadata.Read(metadataPath)
+			if err != nil {
+				t.Fatalf("Read() error = %v", err)
+			}
+
+			if len(m.Installation.Versions) != 1 {
+				t.Fatalf("Read() returned %d versions, want 1", len(m.Installation.Versions))
+			}
+
+			if got := m.Installation.Versions[0].AppImage; got != tt.want {
+				t.Errorf("Read() AppImage = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package appimages
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	filePrefix = "package-manager-"
+	dirIcon    = ".DirIcon"
+)
+
+// BinaryName returns name that is used for AppImage binary (e. g. "Obsidian" -> "obsidian").
+func BinaryName(repo string) string {
+	name := strings.ToLower(repo)
+	name = strings.TrimSuffix(name, ".appimage")
+
+	return strings.ReplaceAll(name, " ", "-")
+}
+
+// Integrate creates desktop entry and icon for AppImage in XDG data folder (e. g. "~/.local/share"),
+// so the app is shown in application menus. It returns paths of all created files.
+func Integrate(
+	appImage string, name string, comment string, dataPath string, permissions os.FileMode,
+) ([]string, error) {
+	appImage, err := filepath.Abs(appImage)
+	if err != nil {
+		return nil, fmt.Errorf("error getting abs path for AppImage: %w", err)
+	}
+
+	var created []string
+
+	// Icon is optional, so the app can still be integrated without it.
+	icon, err := extractIcon(appImage, name, filepath.Join(dataPath, "icons"), permissions)
+	if err == nil {
+		created = append(created, icon)
+	} else {
+		icon = name
+	}
+
+	applicationsPath := filepath.Join(dataPath, "applications")
+	if err := os.MkdirAll(applicationsPath, permissions); err != nil {
+		return created, fmt.Errorf("error creating folder '%s' for desktop entries: %w", applicationsPath, err)
+	}
+
+	entryPath := filepath.Join(applicationsPath, filePrefix+name+".desktop")
+
+	entry := desktopEntry(appImage, name, comment, icon)
+	if err := os.WriteFile(entryPath, []byte(entry), permissions); err != nil {
+		return created, fmt.Errorf("error writing desktop entry '%s': %w", entryPath, err)
+	}
+
+	created = append(created, entryPath)
+
+	return created, nil
+}
+
+func desktopEntry(appImage string, name string, comment string, icon string) string {
+	lines := []string{
+		"[Desktop Entry]",
+		"Type=Application",
+		"Name=" + name,
+		"Comment=" + strings.ReplaceAll(comment, "\n", " "),
+		fmt.Sprintf("Exec=%q %%U", appImage),
+		"Icon=" + icon,
+		"Terminal=false",
+		"Categories=Utility;",
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// extractIcon extracts icon from AppImage and copies it to icons folder.
+// AppImages store icon as ".DirIcon" file in the root folder, and it's usually a symlink to the actual icon.
+func extractIcon(appImage string, name string, iconsPath string, permissions os.FileMode) (string, error) {
+	tempDir, err := os.MkdirTemp("", filePrefix+"appimage-")
+	if err != nil {
+		return "", fmt.Errorf("error creating temp folder: %w", err)
+	}
+
+	defer func(path string) {
+		_ = os.RemoveAll(path)
+	}(tempDir)
+
+	iconPath, err := extractFile(appImage, tempDir, dirIcon)
+	if err != nil {
+		return "", err
+	}
+
+	if target, err := os.Readlink(iconPath); err == nil {
+		if target, err = iconTarget(target); err != nil {
+			return "", err
+		}
+
+		if iconPath, err = extractFile(appImage, tempDir, target); err != nil {
+			return "", err
+		}
+	}
+
+	if err := os.MkdirAll(iconsPath, permissions); err != nil {
+		return "", fmt.Errorf("error creating folder '%s' for icons: %w", iconsPath, err)
+	}
+
+	ext := filepath.Ext(iconPath)
+	if ext == "" {
+		ext = ".png"
+	}
+
+	dest := filepath.Join(iconsPath, filePrefix+name+ext)
+
+	if err := copyFile(iconPath, dest, permissions); err != nil {
+		return "", err
+	}
+
+	return dest, nil
+}
+
+// iconTarget returns cleaned target of ".DirIcon" symlink.
+// Targets must be relative and stay inside AppImage, so files outside of extraction folder are never copied.
+func iconTarget(target string) (string, error) {
+	cleaned := filepath.Clean(target)
+
+	if filepath.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("icon symlink target '%s' points outside of AppImage", target)
+	}
+
+	return cleaned, nil
+}
+
+// extractFile extracts single file from AppImage using its built-in "--appimage-extract" option.
+func extractFile(appImage string, dir string, file string) (string, error) {
+	cmd := exec.Command(appImage, "--appimage-extract", file)
+	cmd.Dir = dir
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("error extracting '%s' from AppImage: %w: %s", file, err, output)
+	}
+
+	path := filepath.Join(dir, "squashfs-root", file)
+	if _, err := os.Lstat(path); errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("AppImage doesn't contain '%s'", file)
+	}
+
+	return path, nil
+}
+
+func copyFile(src string, dest string, permissions os.FileMode) error {
+	srcFile, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("error opening file: %w", err)
+	}
+
+	defer func(file *os.File) {
+		_ = file.Close()
+	}(srcFile)
+
+	destFile, err := os.OpenFile(dest, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, permissions)
+	if err != nil {
+		return fmt.Errorf("error creating file: %w", err)
+	}
+
+	defer func(file *os.File) {
+		_ = file.Close()
+	}(destFile)
+
+	if _, err := io.Copy(destFile, srcFile); err != nil {
+		return fmt.Errorf("error copying file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package appimages
+
+import "testing"
+
+func TestIconTarget(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		target  string
+		want    string
+		wantErr bool
+	}{
+		{name: "file", target: "obsidian.png", want: "obsidian.png", wantErr: false},
+		{name: "nested file", target: "usr/share/icons/obsidian.png", want: "usr/share/icons/obsidian.png", wantErr: false},
+		{name: "dot segments inside", target: "./usr/../obsidian.png", want: "obsidian.png", wantErr: false},
+		{name: "dots in name", target: "..obsidian.png", want: "..obsidian.png", wantErr: false},
+		{name: "absolute", target: "/etc/passwd", want: "", wantErr: true},
+		{name: "parent", target: "..", want: "", wantErr: true},
+		{name: "escaping", target: "../../etc/passwd", want: "", wantErr: true},
+		{name: "escaping after clean", target: "usr/../../etc/passwd", want: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := iconTarget(tt.target)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("iconTarget() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("iconTarget() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package archives
+
+import (
+	"compress/bzip2"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
+)
+
+const (
+	CompressionNone  = Compression("")
+	CompressionGzip  = Compression("gzip")
+	CompressionXz    = Compression("xz")
+	CompressionBzip2 = Compression("bzip2")
+	CompressionZstd  = Compression("zstd")
+)
+
+type Compression string
+
+// Decompress decompresses single compressed file (e. g. "tool-linux-amd64.gz") and saves it to dest folder.
+func Decompress(src string, dest string, name string, compression Compression, permissions os.FileMode) error {
+	if err := os.MkdirAll(dest, permissions); err != nil && !errors.Is(err, os.ErrExist) {
+		return fmt.Errorf("error creating folder for downloads: %w", err)
+	}
+
+	srcFile, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("error opening compressed file: %w", err)
+	}
+
+	defer func(file *os.File) {
+		_ = file.Close()
+	}(srcFile)
+
+	r, err := decompress(srcFile, compression)
+	if err != nil {
+		return err
+	}
+
+	defer func(r io.ReadCloser) {
+		_ = r.Close()
+	}(r)
+
+	destPath, err := sanitizeExtractPath(dest, name)
+	if err != nil {
+		return err
+	}
+
+	return extractFile(r, destPath)
+}
+
+// decompress wraps reader with decompressor for the specified compression.
+func decompress(r io.Reader, compression Compression) (io.ReadCloser, error) {
+	switch compression {
+	case CompressionNone:
+		return io.NopCloser(r), nil
+	case CompressionGzip:
+		gzipReader, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("error creating gzip reader: %w", err)
+		}
+
+		return gzipReader, nil
+	case CompressionXz:
+		xzReader, err := xz.NewReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("error creating xz reader: %w", err)
+		}
+
+		return io.NopCloser(xzReader), nil
+	case CompressionBzip2:
+		return io.NopCloser(bzip2.NewReader(r)), nil
+	case CompressionZstd:
+		zstdReader, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("error creating zstd reader: %w", err)
+		}
+
+		return zstdReader.IOReadCloser(), nil
+	}
+
+	return nil, fmt.Errorf("unsupported compression '%s'", compression)
+}
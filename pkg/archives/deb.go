@@ -0,0 +1,131 @@
+package archives
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	arHeaderSize   = 60
+	arNameSize     = 16
+	arSizeStart    = 48
+	arSizeEnd      = 58
+	debDataPrefix  = "data.tar"
+	debPackageFile = "debian-binary"
+)
+
+var arMagic = []byte("!<arch>\n") //nolint:gochecknoglobals
+
+// ExtractDeb extracts files from data archive of deb package.
+// Files are extracted relative to dest folder (e. g. "usr/bin/tool" -> "dest/usr/bin/tool"),
+// so the package can be installed without root permissions.
+func ExtractDeb(src string, dest string, permissions os.FileMode) error {
+	if err := os.MkdirAll(dest, permissions); err != nil && !errors.Is(err, os.ErrExist) {
+		return fmt.Errorf("error creating folder for downloads: %w", err)
+	}
+
+	srcFile, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("error opening deb package: %w", err)
+	}
+
+	defer func(file *os.File) {
+		_ = file.Close()
+	}(srcFile)
+
+	r := bufio.NewReader(srcFile)
+
+	magic := make([]byte, len(arMagic))
+	if _, err := io.ReadFull(r, magic); err != nil || !bytes.Equal(magic, arMagic) {
+		return fmt.Errorf("deb package isn't an ar archive")
+	}
+
+	for {
+		name, size, err := readArHeader(r)
+		if errors.Is(err, io.EOF) {
+			return fmt.Errorf("deb package doesn't contain data archive")
+		} else if err != nil {
+			return err
+		}
+
+		entry := io.LimitReader(r, size)
+
+		if strings.HasPrefix(name, debDataPrefix) {
+			return extractCompressedTar(entry, dest, permissions)
+		}
+
+		// Entries are aligned to 2 bytes.
+		if _, err := io.CopyN(io.Discard, r, size+size%2); err != nil {
+			return fmt.Errorf("error skipping ar entry '%s': %w", name, err)
+		}
+	}
+}
+
+// readArHeader reads ar entry header and returns entry name and size.
+func readArHeader(r io.Reader) (string, int64, error) {
+	header := make([]byte, arHeaderSize)
+
+	if _, err := io.ReadFull(r, header); errors.Is(err, io.EOF) {
+		return "", 0, io.EOF
+	} else if err != nil {
+		return "", 0, fmt.Errorf("error reading ar entry header: %w", err)
+	}
+
+	// GNU ar adds "/" to the end of names.
+	name := strings.TrimRight(string(header[:arNameSize]), " /")
+
+	size, err := strconv.ParseInt(strings.TrimSpace(string(header[arSizeStart:arSizeEnd])), 10, 64)
+	if err != nil {
+		return "", 0, fmt.Errorf("error parsing size of ar entry '%s': %w", name, err)
+	}
+
+	if size < 0 {
+		return "", 0, fmt.Errorf("invalid size of ar entry '%s': %d", name, size)
+	}
+
+	return name, size, nil
+}
+
+// extractCompressedTar extracts tar archive with compression detected using its header.
+func extractCompressedTar(r io.Reader, dest string, permissions os.FileMode) error {
+	br := bufio.NewReader(r)
+
+	compression, err := peekCompression(br)
+	if err != nil {
+		return err
+	}
+
+	decompressed, err := decompress(br, compression)
+	if err != nil {
+		return err
+	}
+
+	defer func(r io.ReadCloser) {
+		_ = r.Close()
+	}(decompressed)
+
+	return extractEntries(tar.NewReader(decompressed), dest, permissions)
+}
+
+// peekCompression detects compression of the stream without consuming it.
+func peekCompression(r *bufio.Reader) (Compression, error) {
+	header, err := r.Peek(headerSize)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return CompressionNone, fmt.Errorf("error reading header: %w", err)
+	}
+
+	for _, m := range compressionMagics {
+		if bytes.HasPrefix(header, m.magic) {
+			return m.compression, nil
+		}
+	}
+
+	return CompressionNone, nil
+}
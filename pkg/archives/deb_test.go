@@ -0,0 +1,46 @@
+package archives
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func arHeader(name string, size string) string {
+	return fmt.Sprintf("%-16s%-12s%-6s%-6s%-8s%-10s`\n", name, "0", "0", "0", "100644", size)
+}
+
+func TestReadArHeader(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		header   string
+		wantName string
+		wantSize int64
+		wantErr  bool
+	}{
+		{name: "bsd name", header: arHeader("debian-binary", "4"), wantName: "debian-binary", wantSize: 4},
+		{name: "gnu name", header: arHeader("data.tar.xz/", "1024"), wantName: "data.tar.xz", wantSize: 1024},
+		{name: "invalid size", header: arHeader("data.tar", "abc"), wantErr: true},
+		{name: "negative size", header: arHeader("data.tar", "-10"), wantErr: true},
+		{name: "truncated header", header: "data.tar", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			name, size, err := readArHeader(bytes.NewReader([]byte(tt.header)))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("readArHeader() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err == nil && (name != tt.wantName || size != tt.wantSize) {
+				t.Errorf("readArHeader() = %q, %d, want %q, %d", name, size, tt.wantName, tt.wantSize)
+			}
+		})
+	}
+}
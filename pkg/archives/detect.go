@@ -0,0 +1,159 @@
+package archives
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+const (
+	headerSize    = 512
+	tarMagicStart = 257
+)
+
+//nolint:gochecknoglobals
+var (
+	compressionMagics = []struct {
+		magic       []byte
+		compression Compression
+	}{
+		{[]byte{0x1f, 0x8b}, CompressionGzip},
+		{[]byte{0xfd, '7', 'z', 'X', 'Z', 0x00}, CompressionXz},
+		{[]byte{0x28, 0xb5, 0x2f, 0xfd}, CompressionZstd},
+		{[]byte("BZh"), CompressionBzip2},
+	}
+	zipMagics = [][]byte{
+		[]byte("PK\x03\x04"),
+		// Empty archive.
+		[]byte("PK\x05\x06"),
+	}
+	executableMagics = [][]byte{
+		// ELF.
+		{0x7f, 'E', 'L', 'F'},
+		// Mach-O (32-bit, 64-bit and universal).
+		{0xfe, 0xed, 0xfa, 0xce},
+		{0xfe, 0xed, 0xfa, 0xcf},
+		{0xce, 0xfa, 0xed, 0xfe},
+		{0xcf, 0xfa, 0xed, 0xfe},
+		{0xca, 0xfe, 0xba, 0xbe},
+		// PE.
+		[]byte("MZ"),
+		// Script with shebang.
+		[]byte("#!"),
+	}
+	tarMagic = []byte("ustar")
+	// AppImages are ELF files with "AI" and AppImage type (1 or 2) at offset 8.
+	appImageMagics = [][]byte{
+		{0x7f, 'E', 'L', 'F', 0, 0, 0, 0, 'A', 'I', 0x01},
+		{0x7f, 'E', 'L', 'F', 0, 0, 0, 0, 'A', 'I', 0x02},
+	}
+)
+
+// ErrUnknownFormat is returned when file is neither an archive, compressed file nor executable.
+var ErrUnknownFormat = errors.New("unknown file format")
+
+// Detect detects format of the file using its header.
+// Compressed files are decompressed partially to check whether they contain tar archive.
+// Executables (ELF, Mach-O, PE and scripts) have neither container nor compression.
+// AppImages are detected as a separate container type.
+func Detect(src string) (Format, error) {
+	header, err := readHeader(src, CompressionNone)
+	if err != nil {
+		return Format{}, err
+	}
+
+	if bytes.HasPrefix(header, arMagic) {
+		return Format{ContainerDeb, CompressionNone}, nil
+	}
+
+	if bytes.HasPrefix(header, rpmMagic) {
+		return Format{ContainerRpm, CompressionNone}, nil
+	}
+
+	for _, m := range zipMagics {
+		if bytes.HasPrefix(header, m) {
+			return Format{ContainerZip, CompressionNone}, nil
+		}
+	}
+
+	if isTar(header) {
+		return Format{ContainerTar, CompressionNone}, nil
+	}
+
+	for _, m := range compressionMagics {
+		if !bytes.HasPrefix(header, m.magic) {
+			continue
+		}
+
+		decompressed, err := readHeader(src, m.compression)
+		if err != nil {
+			return Format{}, err
+		}
+
+		if isTar(decompressed) {
+			return Format{ContainerTar, m.compression}, nil
+		}
+
+		return Format{ContainerNone, m.compression}, nil
+	}
+
+	if isAppImage(header) {
+		return Format{ContainerAppImage, CompressionNone}, nil
+	}
+
+	for _, m := range executableMagics {
+		if bytes.HasPrefix(header, m) {
+			return Format{ContainerNone, CompressionNone}, nil
+		}
+	}
+
+	return Format{}, fmt.Errorf("error detecting format of file '%s': %w", src, ErrUnknownFormat)
+}
+
+// readHeader reads the beginning of the file decompressed with the specified compression.
+func readHeader(src string, compression Compression) ([]byte, error) {
+	file, err := os.Open(src)
+	if err != nil {
+		return nil, fmt.Errorf("error opening file: %w", err)
+	}
+
+	defer func(file *os.File) {
+		_ = file.Close()
+	}(file)
+
+	r, err := decompress(file, compression)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func(r io.ReadCloser) {
+		_ = r.Close()
+	}(r)
+
+	header := make([]byte, headerSize)
+
+	n, err := io.ReadFull(r, header)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, fmt.Errorf("error reading file header: %w", err)
+	}
+
+	return header[:n], nil
+}
+
+func isAppImage(header []byte) bool {
+	for _, m := range appImageMagics {
+		// Bytes 4-7 contain ELF class, data encoding, version and ABI and may vary.
+		if len(header) >= len(m) && bytes.HasPrefix(header, m[:4]) && bytes.Equal(header[8:len(m)], m[8:]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isTar(header []byte) bool {
+	return len(header) >= tarMagicStart+len(tarMagic) &&
+		bytes.Equal(header[tarMagicStart:tarMagicStart+len(tarMagic)], tarMagic)
+}
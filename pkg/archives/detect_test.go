@@ -0,0 +1,197 @@
+package archives
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
+)
+
+//nolint:gochecknoglobals
+var (
+	// bzip2Binary and bzip2Tar are "binary\n" and ustar archive with "tool" file containing it,
+	// compressed with "bzip2 -9", as there is no bzip2 compressor in the standard library.
+	bzip2Binary = []byte{
+		0x42, 0x5a, 0x68, 0x39, 0x31, 0x41, 0x59, 0x26, 0x53, 0x59, 0x82, 0x03, 0xa1, 0x8c, 0x00, 0x00,
+		0x01, 0x41, 0x80, 0x00, 0x10, 0x30, 0x21, 0x10, 0x20, 0x20, 0x00, 0x31, 0x0c, 0x01, 0x06, 0x9b,
+		0x44, 0x13, 0x09, 0x45, 0xdc, 0x91, 0x4e, 0x14, 0x24, 0x20, 0x80, 0xe8, 0x63, 0x00,
+	}
+	bzip2Tar = []byte{
+		0x42, 0x5a, 0x68, 0x39, 0x31, 0x41, 0x59, 0x26, 0x53, 0x59, 0xff, 0x40, 0x3c, 0xcd, 0x00, 0x00,
+		0x84, 0xfb, 0x90, 0xc9, 0x10, 0x00, 0x48, 0x40, 0x00, 0x77, 0x80, 0x00, 0x04, 0x70, 0x25, 0x9e,
+		0x20, 0x04, 0x00, 0x00, 0x08, 0x20, 0x00, 0x75, 0x11, 0x34, 0xd4, 0xf4, 0xd4, 0x0c, 0x87, 0x94,
+		0x1a, 0x7a, 0x9e, 0xa0, 0x92, 0x93, 0xd1, 0x34, 0x68, 0xc8, 0x01, 0xa0, 0x54, 0xfb, 0x89, 0x41,
+		0x90, 0x82, 0x36, 0xa4, 0x24, 0x66, 0xfa, 0x38, 0xab, 0x11, 0x82, 0x04, 0x39, 0x1e, 0xd8, 0x14,
+		0x61, 0x06, 0xcd, 0x61, 0x90, 0x33, 0x1c, 0x89, 0x4b, 0x53, 0x21, 0x1c, 0xe9, 0x46, 0x44, 0x54,
+		0x30, 0x5b, 0xc5, 0xdd, 0xc4, 0xd8, 0x26, 0x0f, 0xc6, 0xd3, 0x8c, 0x02, 0x3e, 0x8c, 0x6c, 0x92,
+		0x0f, 0xc5, 0xdc, 0x91, 0x4e, 0x14, 0x24, 0x3f, 0xd0, 0x0f, 0x33, 0x40,
+	}
+	elfHeader = []byte{0x7f, 'E', 'L', 'F', 0x02, 0x01, 0x01, 0x00}
+)
+
+func tarBytes(t *testing.T) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	w := tar.NewWriter(&buf)
+
+	content := []byte("binary\n")
+
+	h := &tar.Header{Name: "tool", Mode: 0o755, Size: int64(len(content))} //nolint:exhaustivestruct
+	if err := w.WriteHeader(h); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := w.Write(content); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func gzipBytes(t *testing.T, b []byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(b); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func xzBytes(t *testing.T, b []byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	w, err := xz.NewWriter(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := w.Write(b); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func zstdBytes(t *testing.T, b []byte) []byte {
+	t.Helper()
+
+	enc, err := zstd.NewWriter(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func(enc *zstd.Encoder) {
+		_ = enc.Close()
+	}(enc)
+
+	return enc.EncodeAll(b, nil)
+}
+
+func TestDetect(t *testing.T) {
+	t.Parallel()
+
+	tarball := tarBytes(t)
+	binary := append(append([]byte{}, elfHeader...), "binary"...)
+
+	tests := []struct {
+		name    string
+		content []byte
+		want    Format
+		wantErr bool
+		// wantUnknown is set if ErrUnknownFormat is expected.
+		wantUnknown bool
+	}{
+		{name: "tar", content: tarball, want: Format{ContainerTar, CompressionNone}},
+		{name: "tar.gz", content: gzipBytes(t, tarball), want: Format{ContainerTar, CompressionGzip}},
+		{name: "gz", content: gzipBytes(t, binary), want: Format{ContainerNone, CompressionGzip}},
+		{name: "tar.xz", content: xzBytes(t, tarball), want: Format{ContainerTar, CompressionXz}},
+		{name: "xz", content: xzBytes(t, binary), want: Format{ContainerNone, CompressionXz}},
+		{name: "tar.zst", content: zstdBytes(t, tarball), want: Format{ContainerTar, CompressionZstd}},
+		{name: "zst", content: zstdBytes(t, binary), want: Format{ContainerNone, CompressionZstd}},
+		{name: "tar.bz2", content: bzip2Tar, want: Format{ContainerTar, CompressionBzip2}},
+		{name: "bz2", content: bzip2Binary, want: Format{ContainerNone, CompressionBzip2}},
+		{name: "zip", content: []byte("PK\x03\x04\x14\x00\x00\x00"), want: Format{ContainerZip, CompressionNone}},
+		{name: "empty zip", content: []byte("PK\x05\x06" + string(make([]byte, 18))),
+			want: Format{ContainerZip, CompressionNone}},
+		{name: "deb", content: []byte("!<arch>\ndebian-binary   "), want: Format{ContainerDeb, CompressionNone}},
+		{name: "rpm", content: []byte{0xed, 0xab, 0xee, 0xdb, 0x03, 0x00}, want: Format{ContainerRpm, CompressionNone}},
+		{name: "elf", content: binary, want: Format{ContainerNone, CompressionNone}},
+		{
+			name:    "appimage type 1",
+			content: append(append([]byte{}, elfHeader...), 'A', 'I', 0x01, 0x00),
+			want:    Format{ContainerAppImage, CompressionNone},
+		},
+		{
+			name:    "appimage type 2",
+			content: append(append([]byte{}, elfHeader...), 'A', 'I', 0x02, 0x00),
+			want:    Format{ContainerAppImage, CompressionNone},
+		},
+		{
+			name:    "elf with unknown appimage type",
+			content: append(append([]byte{}, elfHeader...), 'A', 'I', 0x03, 0x00),
+			want:    Format{ContainerNone, CompressionNone},
+		},
+		{name: "truncated appimage", content: append(append([]byte{}, elfHeader...), 'A', 'I'),
+			want: Format{ContainerNone, CompressionNone}},
+		{name: "mach-o", content: []byte{0xcf, 0xfa, 0xed, 0xfe, 0x07}, want: Format{ContainerNone, CompressionNone}},
+		{name: "pe", content: []byte("MZ\x90\x00"), want: Format{ContainerNone, CompressionNone}},
+		{name: "script", content: []byte("#!/bin/sh\necho tool\n"), want: Format{ContainerNone, CompressionNone}},
+		{name: "truncated gzip", content: []byte{0x1f, 0x8b}, wantErr: true},
+		{name: "truncated xz", content: []byte{0xfd, '7', 'z', 'X', 'Z', 0x00}, wantErr: true},
+		{name: "truncated elf", content: []byte{0x7f, 'E', 'L'}, wantErr: true, wantUnknown: true},
+		{name: "text", content: []byte("hello world\n"), wantErr: true, wantUnknown: true},
+		{name: "empty", content: nil, wantErr: true, wantUnknown: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "asset")
+			if err := os.WriteFile(path, tt.content, 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := Detect(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Detect() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantUnknown && !errors.Is(err, ErrUnknownFormat) {
+				t.Errorf("Detect() error = %v, want %v", err, ErrUnknownFormat)
+			}
+
+			if got != tt.want {
+				t.Errorf("Detect() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package archives
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	ContainerNone = Container("")
+	ContainerTar  = Container("tar")
+	ContainerZip  = Container("zip")
+	ContainerDeb  = Container("deb")
+	ContainerRpm  = Container("rpm")
+	// ContainerAppImage is a self-mounting executable that is used as is and is never extracted.
+	ContainerAppImage = Container("appimage")
+)
+
+// Container is a type of archive that stores multiple files.
+type Container string
+
+// Format describes how asset should be unpacked.
+// Archives have both container and compression (e. g. tar + xz),
+// while compressed binaries have only compression (e. g. gzip).
+type Format struct {
+	Container
+	Compression
+}
+
+type formatSuffix struct {
+	ext    string
+	format Format
+}
+
+//nolint:gochecknoglobals
+var (
+	// archiveSuffixes are checked in order, so longer extensions must go first.
+	archiveSuffixes = []formatSuffix{
+		{".tar.gz", Format{ContainerTar, CompressionGzip}},
+		{".tgz", Format{ContainerTar, CompressionGzip}},
+		{".tar.xz", Format{ContainerTar, CompressionXz}},
+		{".txz", Format{ContainerTar, CompressionXz}},
+		{".tar.bz2", Format{ContainerTar, CompressionBzip2}},
+		{".tbz2", Format{ContainerTar, CompressionBzip2}},
+		{".tbz", Format{ContainerTar, CompressionBzip2}},
+		{".tar.zst", Format{ContainerTar, CompressionZstd}},
+		{".tzst", Format{ContainerTar, CompressionZstd}},
+		{".tar", Format{ContainerTar, CompressionNone}},
+		{".zip", Format{ContainerZip, CompressionNone}},
+		{".deb", Format{ContainerDeb, CompressionNone}},
+		{".rpm", Format{ContainerRpm, CompressionNone}},
+		{".appimage", Format{ContainerAppImage, CompressionNone}},
+	}
+	compressionSuffixes = []formatSuffix{
+		{".gz", Format{ContainerNone, CompressionGzip}},
+		{".xz", Format{ContainerNone, CompressionXz}},
+		{".bz2", Format{ContainerNone, CompressionBzip2}},
+		{".zst", Format{ContainerNone, CompressionZstd}},
+	}
+)
+
+// FormatFromName detects format of asset using its file name.
+// Files without known extensions are considered to be uncompressed binaries.
+func FormatFromName(name string) Format {
+	name = strings.ToLower(name)
+
+	for _, suffixes := range [][]formatSuffix{archiveSuffixes, compressionSuffixes} {
+		for _, suffix := range suffixes {
+			if strings.HasSuffix(name, suffix.ext) {
+				return suffix.format
+			}
+		}
+	}
+
+	return Format{ContainerNone, CompressionNone}
+}
+
+// IsArchive reports whether format contains multiple files that must be extracted.
+func (f Format) IsArchive() bool {
+	return f.Container != ContainerNone && f.Container != ContainerAppImage
+}
+
+// IsAppImage reports whether format is an AppImage executable.
+func (f Format) IsAppImage() bool {
+	return f.Container == ContainerAppImage
+}
+
+// IsSystemPackage reports whether format is a package of system package manager (deb or rpm).
+// Files of such packages are stored relative to filesystem root (e. g. "usr/bin/tool").
+func (f Format) IsSystemPackage() bool {
+	return f.Container == ContainerDeb || f.Container == ContainerRpm
+}
+
+// IsCompressed reports whether format requires decompression.
+func (f Format) IsCompressed() bool {
+	return f.Compression != CompressionNone
+}
+
+// Extract unpacks archive or compressed file to dest folder.
+// Compressed binaries are saved to dest folder with the specified name.
+func Extract(src string, dest string, name string, format Format, permissions os.FileMode) error {
+	switch format.Container {
+	case ContainerTar:
+		return ExtractTar(src, dest, format.Compression, permissions)
+	case ContainerZip:
+		return ExtractZip(src, dest, permissions)
+	case ContainerDeb:
+		return ExtractDeb(src, dest, permissions)
+	case ContainerRpm:
+		return ExtractRpm(src, dest, permissions)
+	case ContainerNone, ContainerAppImage:
+	}
+
+	if format.IsCompressed() {
+		return Decompress(src, dest, name, format.Compression, permissions)
+	}
+
+	return fmt.Errorf("file is neither an archive nor a compressed file")
+}
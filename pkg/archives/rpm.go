@@ -0,0 +1,242 @@
+package archives
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+const (
+	rpmLeadSize        = 96
+	rpmHeaderIntroSize = 16
+	rpmIndexEntrySize  = 16
+	rpmHeaderAlignment = 8
+
+	cpioHeaderSize = 110
+	cpioAlignment  = 4
+	cpioTrailer    = "TRAILER!!!"
+	// cpioMaxNameSize and cpioMaxLinkSize limit memory allocated for names and symlink targets of malformed entries.
+	cpioMaxNameSize = 4096
+	cpioMaxLinkSize = 4096
+
+	cpioModeType    = 0o170000
+	cpioModeDir     = 0o040000
+	cpioModeRegular = 0o100000
+	cpioModeSymlink = 0o120000
+)
+
+//nolint:gochecknoglobals
+var (
+	rpmMagic       = []byte{0xed, 0xab, 0xee, 0xdb}
+	rpmHeaderMagic = []byte{0x8e, 0xad, 0xe8}
+	cpioMagics     = []string{"070701", "070702"}
+)
+
+// ExtractRpm extracts files from cpio payload of rpm package.
+// Files are extracted relative to dest folder (e. g. "usr/bin/tool" -> "dest/usr/bin/tool"),
+// so the package can be installed without root permissions.
+func ExtractRpm(src string, dest string, permissions os.FileMode) error {
+	if err := os.MkdirAll(dest, permissions); err != nil && !errors.Is(err, os.ErrExist) {
+		return fmt.Errorf("error creating folder for downloads: %w", err)
+	}
+
+	srcFile, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("error opening rpm package: %w", err)
+	}
+
+	defer func(file *os.File) {
+		_ = file.Close()
+	}(srcFile)
+
+	r := bufio.NewReader(srcFile)
+
+	lead := make([]byte, rpmLeadSize)
+	if _, err := io.ReadFull(r, lead); err != nil || !bytes.HasPrefix(lead, rpmMagic) {
+		return fmt.Errorf("file isn't an rpm package")
+	}
+
+	// Signature header is padded to 8 bytes, main header isn't.
+	if err := skipRpmHeader(r, true); err != nil {
+		return fmt.Errorf("error reading signature header: %w", err)
+	}
+
+	if err := skipRpmHeader(r, false); err != nil {
+		return fmt.Errorf("error reading main header: %w", err)
+	}
+
+	compression, err := peekCompression(r)
+	if err != nil {
+		return err
+	}
+
+	payload, err := decompress(r, compression)
+	if err != nil {
+		return err
+	}
+
+	defer func(r io.ReadCloser) {
+		_ = r.Close()
+	}(payload)
+
+	return extractCpio(bufio.NewReader(payload), dest, permissions)
+}
+
+func skipRpmHeader(r io.Reader, aligned bool) error {
+	intro := make([]byte, rpmHeaderIntroSize)
+	if _, err := io.ReadFull(r, intro); err != nil {
+		return fmt.Errorf("error reading header intro: %w", err)
+	}
+
+	if !bytes.HasPrefix(intro, rpmHeaderMagic) {
+		return fmt.Errorf("invalid header magic")
+	}
+
+	entries := int64(binary.BigEndian.Uint32(intro[8:12]))
+	dataSize := int64(binary.BigEndian.Uint32(intro[12:16]))
+
+	size := entries*rpmIndexEntrySize + dataSize
+	if aligned && size%rpmHeaderAlignment != 0 {
+		size += rpmHeaderAlignment - size%rpmHeaderAlignment
+	}
+
+	if _, err := io.CopyN(io.Discard, r, size); err != nil {
+		return fmt.Errorf("error skipping header: %w", err)
+	}
+
+	return nil
+}
+
+// extractCpio extracts cpio archive in "newc" format.
+func extractCpio(r io.Reader, dest string, permissions os.FileMode) error {
+	for {
+		name, mode, size, err := readCpioHeader(r)
+		if err != nil {
+			return err
+		}
+
+		if name == cpioTrailer {
+			return removeEscapingSymlinks(dest)
+		}
+
+		entry := io.LimitReader(r, size)
+
+		if err := extractCpioEntry(entry, dest, name, mode, size, permissions); err != nil {
+			return err
+		}
+
+		// Discard the rest of the entry if it wasn't read completely and skip padding.
+		if _, err := io.Copy(io.Discard, entry); err != nil {
+			return fmt.Errorf("error skipping cpio entry '%s': %w", name, err)
+		}
+
+		if _, err := io.CopyN(io.Discard, r, padding(size, cpioAlignment)); err != nil {
+			return fmt.Errorf("error skipping cpio padding: %w", err)
+		}
+	}
+}
+
+func extractCpioEntry(r io.Reader, dest string, name string, mode int64, size int64, permissions os.FileMode) error {
+	entryPath, err := sanitizeExtractPath(dest, name)
+	if err != nil {
+		return err
+	}
+
+	switch mode & cpioModeType {
+	case cpioModeDir:
+		if err := os.MkdirAll(entryPath, permissions); err != nil {
+			return fmt.Errorf("error creating folder: %w", err)
+		}
+	case cpioModeRegular:
+		if err := os.MkdirAll(filepath.Dir(entryPath), permissions); err != nil {
+			return fmt.Errorf("error creating folder: %w", err)
+		}
+
+		if err := extractFile(r, entryPath); err != nil {
+			return err
+		}
+	case cpioModeSymlink:
+		if size > cpioMaxLinkSize {
+			return fmt.Errorf("symlink target of cpio entry '%s' is too long: %d bytes", name, size)
+		}
+
+		target := make([]byte, size)
+		if _, err := io.ReadFull(r, target); err != nil {
+			return fmt.Errorf("error reading symlink target: %w", err)
+		}
+
+		if err := extractSymlink(dest, entryPath, string(target), permissions); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unexpected mode '%o' when extracting file '%s' in cpio archive", mode, name)
+	}
+
+	return nil
+}
+
+// readCpioHeader reads header and name of the entry and returns entry name, mode and size.
+func readCpioHeader(r io.Reader) (string, int64, int64, error) {
+	header := make([]byte, cpioHeaderSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return "", 0, 0, fmt.Errorf("error reading cpio header: %w", err)
+	}
+
+	if !isCpioMagic(string(header[:6])) {
+		return "", 0, 0, fmt.Errorf("unsupported cpio format")
+	}
+
+	// Header fields are 8-character hex numbers following 6-character magic.
+	field := func(i int) (int64, error) {
+		start := 6 + i*8
+
+		return strconv.ParseInt(string(header[start:start+8]), 16, 64)
+	}
+
+	mode, err := field(1)
+	if err != nil {
+		return "", 0, 0, fmt.Errorf("error parsing cpio entry mode: %w", err)
+	}
+
+	size, err := field(6)
+	if err != nil {
+		return "", 0, 0, fmt.Errorf("error parsing cpio entry size: %w", err)
+	}
+
+	nameSize, err := field(11)
+	if err != nil {
+		return "", 0, 0, fmt.Errorf("error parsing cpio entry name size: %w", err)
+	}
+
+	if nameSize > cpioMaxNameSize {
+		return "", 0, 0, fmt.Errorf("cpio entry name is too long: %d bytes", nameSize)
+	}
+
+	// Name is followed by NUL and padded together with the header.
+	name := make([]byte, nameSize+padding(cpioHeaderSize+nameSize, cpioAlignment))
+	if _, err := io.ReadFull(r, name); err != nil {
+		return "", 0, 0, fmt.Errorf("error reading cpio entry name: %w", err)
+	}
+
+	return string(bytes.TrimRight(name[:nameSize], "\x00")), mode, size, nil
+}
+
+func isCpioMagic(magic string) bool {
+	for _, m := range cpioMagics {
+		if magic == m {
+			return true
+		}
+	}
+
+	return false
+}
+
+func padding(size int64, alignment int64) int64 {
+	return (alignment - size%alignment) % alignment
+}
@@ -0,0 +1,142 @@
+package archives
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// cpioEntry returns entry in "newc" format. Name size from header is overridden if nameSize isn't negative.
+func cpioEntry(name string, mode int64, content string, nameSize int) string {
+	if nameSize < 0 {
+		nameSize = len(name) + 1
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("070701")
+
+	for _, value := range []int64{0, mode, 0, 0, 1, 0, int64(len(content)), 0, 0, 0, 0, int64(nameSize), 0} {
+		sb.WriteString(fmt.Sprintf("%08x", value))
+	}
+
+	sb.WriteString(name + "\x00")
+	sb.WriteString(strings.Repeat("\x00", int(padding(int64(cpioHeaderSize+len(name)+1), cpioAlignment))))
+	sb.WriteString(content)
+	sb.WriteString(strings.Repeat("\x00", int(padding(int64(len(content)), cpioAlignment))))
+
+	return sb.String()
+}
+
+func TestReadCpioHeader(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		entry    string
+		wantName string
+		wantMode int64
+		wantSize int64
+		wantErr  bool
+	}{
+		{
+			name:     "regular file",
+			entry:    cpioEntry("./usr/bin/tool", cpioModeRegular|0o755, "binary", -1),
+			wantName: "./usr/bin/tool",
+			wantMode: cpioModeRegular | 0o755,
+			wantSize: 6,
+		},
+		{name: "trailer", entry: cpioEntry(cpioTrailer, 0, "", -1), wantName: cpioTrailer},
+		{name: "unsupported magic", entry: "070707" + strings.Repeat("0", cpioHeaderSize-6), wantErr: true},
+		{name: "invalid number", entry: "070701" + strings.Repeat("z", cpioHeaderSize-6), wantErr: true},
+		{name: "huge name", entry: cpioEntry("tool", cpioModeRegular, "", 0x7fffffff), wantErr: true},
+		{name: "truncated name", entry: cpioEntry("tool", cpioModeRegular, "", -1)[:cpioHeaderSize+2], wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			name, mode, size, err := readCpioHeader(bytes.NewReader([]byte(tt.entry)))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("readCpioHeader() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err == nil && (name != tt.wantName || mode != tt.wantMode || size != tt.wantSize) {
+				t.Errorf("readCpioHeader() = %q, %o, %d, want %q, %o, %d",
+					name, mode, size, tt.wantName, tt.wantMode, tt.wantSize)
+			}
+		})
+	}
+}
+
+func TestExtractCpio(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		archive string
+		wantErr bool
+		exists  []string
+		missing []string
+	}{
+		{
+			name: "files and symlinks",
+			archive: cpioEntry("./usr/bin", cpioModeDir|0o755, "", -1) +
+				cpioEntry("./usr/bin/tool", cpioModeRegular|0o755, "binary", -1) +
+				cpioEntry("./usr/bin/alias", cpioModeSymlink|0o777, "tool", -1) +
+				cpioEntry(cpioTrailer, 0, "", -1),
+			exists: []string{"usr/bin/tool", "usr/bin/alias"},
+		},
+		{
+			name: "path outside",
+			archive: cpioEntry("../evil", cpioModeRegular|0o644, "evil", -1) +
+				cpioEntry(cpioTrailer, 0, "", -1),
+			wantErr: true,
+		},
+		{
+			name: "huge symlink target",
+			archive: cpioEntry("link", cpioModeSymlink|0o777, strings.Repeat("a", cpioMaxLinkSize+1), -1) +
+				cpioEntry(cpioTrailer, 0, "", -1),
+			wantErr: true,
+		},
+		{
+			name: "write through symlink",
+			archive: cpioEntry("a/l", cpioModeSymlink|0o777, ".", -1) +
+				cpioEntry("a/l/x", cpioModeRegular|0o644, "x", -1) +
+				cpioEntry(cpioTrailer, 0, "", -1),
+			wantErr: true,
+		},
+		{
+			name:    "missing trailer",
+			archive: cpioEntry("tool", cpioModeRegular|0o644, "x", -1),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dest := filepath.Join(t.TempDir(), "package")
+
+			err := extractCpio(bytes.NewReader([]byte(tt.archive)), dest, 0o755)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("extractCpio() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			for _, p := range tt.exists {
+				if _, err := os.Lstat(filepath.Join(dest, p)); err != nil {
+					t.Errorf("expected '%s' to exist: %v", p, err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package archives
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func ExtractTarGz(src string, dest string, permissions os.FileMode) error {
+	return ExtractTar(src, dest, CompressionGzip, permissions)
+}
+
+// ExtractTar extracts tar archive compressed with the specified compression.
+func ExtractTar(src string, dest string, compression Compression, permissions os.FileMode) error {
+	if err := os.MkdirAll(dest, permissions); err != nil && !errors.Is(err, os.ErrExist) {
+		return fmt.Errorf("error creating folder for downloads: %w", err)
+	}
+
+	srcFile, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("error opening archive file: %w", err)
+	}
+
+	defer func(file *os.File) {
+		_ = file.Close()
+	}(srcFile)
+
+	r, err := decompress(srcFile, compression)
+	if err != nil {
+		return fmt.Errorf("error creating archive reader: %w", err)
+	}
+
+	defer func(r io.ReadCloser) {
+		_ = r.Close()
+	}(r)
+
+	tarReader := tar.NewReader(r)
+
+	if err := extractEntries(tarReader, dest, permissions); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func extractEntries(tarReader *tar.Reader, dest string, permissions os.FileMode) error {
+	for {
+		h, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return fmt.Errorf("error extracting file header: %w", err)
+		}
+
+		entryPath, err := sanitizeExtractPath(dest, h.Name)
+		if err != nil {
+			return err
+		}
+
+		switch h.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(entryPath, permissions); err != nil {
+				return fmt.Errorf("error creating folder: %w", err)
+			}
+		case tar.TypeReg:
+			// Archives may not contain entries for parent folders.
+			if err := os.MkdirAll(filepath.Dir(entryPath), permissions); err != nil {
+				return fmt.Errorf("error creating folder: %w", err)
+			}
+
+			if err := extractFile(tarReader, entryPath); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			if err := extractSymlink(dest, entryPath, h.Linkname, permissions); err != nil {
+				return err
+			}
+		case tar.TypeLink:
+			if err := extractHardLink(dest, entryPath, h.Linkname); err != nil {
+				return err
+			}
+		case tar.TypeXGlobalHeader:
+		default:
+			return fmt.Errorf("unexpected type flag '%v' when extracting file '%s' in tar archive ",
+				h.Typeflag, h.Name)
+		}
+	}
+
+	return removeEscapingSymlinks(dest)
+}
+
+func extractFile(r io.Reader, dest string) error {
+	destFile, err := os.Create(dest)
+	if err != nil {
+		return fmt.Errorf("error creating file: %w", err)
+	}
+
+	defer func(file *os.File) {
+		_ = file.Close()
+	}(destFile)
+
+	if _, err := io.Copy(destFile, r); err != nil {
+		return fmt.Errorf("error copying file contents to the dest folder: %w", err)
+	}
+
+	return nil
+}
+
+// extractSymlink creates symlink if it points to a file inside dest folder.
+// Symlinks with absolute targets or targets outside of dest folder are skipped,
+// as they point to files that aren't part of the package.
+func extractSymlink(dest string, entryPath string, target string, permissions os.FileMode) error {
+	if filepath.IsAbs(target) || !isInside(dest, filepath.Join(filepath.Dir(entryPath), target)) {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(entryPath), permissions); err != nil {
+		return fmt.Errorf("error creating folder: %w", err)
+	}
+
+	if err := os.Symlink(target, entryPath); err != nil {
+		return fmt.Errorf("error creating symlink '%s': %w", entryPath, err)
+	}
+
+	return nil
+}
+
+func extractHardLink(dest string, entryPath string, target string) error {
+	targetPath, err := sanitizeExtractPath(dest, target)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Link(targetPath, entryPath); err != nil {
+		return fmt.Errorf("error creating hard link '%s': %w", entryPath, err)
+	}
+
+	return nil
+}
+
+// sanitizeExtractPath helps to avoid Zip Slip vulnerability (https://snyk.io/research/zip-slip-vulnerability).
+// Paths going through symlinks extracted earlier are rejected, as symlinks can point outside of the folder.
+func sanitizeExtractPath(folder string, file string) (string, error) {
+	p := filepath.Join(folder, file)
+
+	// Archives may contain entry for the root folder itself (e. g. "./").
+	if p == filepath.Clean(folder) {
+		return p, nil
+	}
+
+	if !isInside(folder, p) {
+		return "", fmt.Errorf("archive traversal vulnerability detected")
+	}
+
+	if err := checkNoSymlinks(folder, p); err != nil {
+		return "", err
+	}
+
+	return p, nil
+}
+
+// checkNoSymlinks returns error if any existing component of the path inside folder is a symlink.
+func checkNoSymlinks(folder string, path string) error {
+	rel, err := filepath.Rel(folder, path)
+	if err != nil {
+		return fmt.Errorf("error getting relative path of '%s': %w", path, err)
+	}
+
+	current := filepath.Clean(folder)
+
+	for _, part := range strings.Split(rel, string(os.PathSeparator)) {
+		current = filepath.Join(current, part)
+
+		info, err := os.Lstat(current)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("error checking extracted path '%s': %w", current, err)
+		}
+
+		if info.Mode()&os.ModeSymlink != 0 {
+			return fmt.Errorf("archive traversal vulnerability detected: path '%s' goes through symlink", rel)
+		}
+	}
+
+	return nil
+}
+
+// removeEscapingSymlinks removes extracted symlinks that resolve to files outside of dest folder.
+// Targets of symlinks are checked when they are extracted, but chains of symlinks can still point outside.
+func removeEscapingSymlinks(dest string) error {
+	realDest, err := filepath.EvalSymlinks(dest)
+	if err != nil {
+		return fmt.Errorf("error resolving extraction folder: %w", err)
+	}
+
+	return filepath.WalkDir(dest, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return fmt.Errorf("error walking extracted files: %w", err)
+		}
+
+		if d.Type()&os.ModeSymlink == 0 {
+			return nil
+		}
+
+		resolved, err := filepath.EvalSymlinks(path)
+		if errors.Is(err, os.ErrNotExist) {
+			// Dangling symlinks can't be used to access other files.
+			return nil
+		} else if err == nil && isInside(realDest, resolved) {
+			return nil
+		}
+
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("error removing symlink '%s' pointing outside of package: %w", path, err)
+		}
+
+		return nil
+	})
+}
+
+func isInside(folder string, path string) bool {
+	return strings.HasPrefix(filepath.Clean(path), filepath.Clean(folder)+string(os.PathSeparator))
+}
@@ -0,0 +1,159 @@
+package archives
+
+import (
+	"archive/tar"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type tarEntry struct {
+	name     string
+	typeflag byte
+	linkname string
+	content  string
+}
+
+func writeTar(t *testing.T, path string, entries []tarEntry) {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	w := tar.NewWriter(&buf)
+
+	for _, e := range entries {
+		h := &tar.Header{ //nolint:exhaustivestruct
+			Name:     e.name,
+			Typeflag: e.typeflag,
+			Linkname: e.linkname,
+			Mode:     0o644,
+			Size:     int64(len(e.content)),
+		}
+
+		if err := w.WriteHeader(h); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := w.Write([]byte(e.content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExtractTar(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		entries []tarEntry
+		wantErr bool
+		// exists are paths relative to dest that must exist after extraction.
+		exists []string
+		// missing are paths relative to dest that must not exist after extraction.
+		missing []string
+	}{
+		{
+			name: "regular files and symlinks inside",
+			entries: []tarEntry{
+				{name: "tool/bin/tool", typeflag: tar.TypeReg, content: "binary"},
+				{name: "tool/tool", typeflag: tar.TypeSymlink, linkname: "bin/tool"},
+			},
+			exists: []string{"tool/bin/tool", "tool/tool"},
+		},
+		{
+			name: "relative path outside",
+			entries: []tarEntry{
+				{name: "../evil", typeflag: tar.TypeReg, content: "evil"},
+			},
+			wantErr: true,
+			missing: []string{"../evil"},
+		},
+		{
+			name: "absolute and outside symlinks are skipped",
+			entries: []tarEntry{
+				{name: "abs", typeflag: tar.TypeSymlink, linkname: "/etc/passwd"},
+				{name: "up", typeflag: tar.TypeSymlink, linkname: "../outside"},
+			},
+			missing: []string{"abs", "up"},
+		},
+		{
+			name: "write through chain of symlinks",
+			entries: []tarEntry{
+				{name: "a/b/l", typeflag: tar.TypeSymlink, linkname: ".."},
+				{name: "a/b/l/x", typeflag: tar.TypeSymlink, linkname: "../.."},
+				{name: "a/b/l/x/evil", typeflag: tar.TypeReg, content: "evil"},
+			},
+			wantErr: true,
+			missing: []string{"../evil", "evil"},
+		},
+		{
+			name: "symlink resolving outside through another symlink",
+			entries: []tarEntry{
+				{name: "a/b/m", typeflag: tar.TypeSymlink, linkname: "l/../.."},
+				{name: "a/b/l", typeflag: tar.TypeSymlink, linkname: ".."},
+			},
+			exists:  []string{"a/b/l"},
+			missing: []string{"a/b/m"},
+		},
+		{
+			name: "hard link through symlink",
+			entries: []tarEntry{
+				{name: "a/l", typeflag: tar.TypeSymlink, linkname: "."},
+				{name: "a/h", typeflag: tar.TypeLink, linkname: "a/l/x"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			root := t.TempDir()
+			src := filepath.Join(root, "archive.tar")
+			dest := filepath.Join(root, "staging", "package")
+
+			writeTar(t, src, tt.entries)
+
+			err := ExtractTar(src, dest, CompressionNone, 0o755)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ExtractTar() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			for _, p := range tt.exists {
+				if _, err := os.Lstat(filepath.Join(dest, p)); err != nil {
+					t.Errorf("expected '%s' to exist: %v", p, err)
+				}
+			}
+
+			for _, p := range tt.missing {
+				if _, err := os.Lstat(filepath.Join(dest, p)); err == nil {
+					t.Errorf("expected '%s' to be missing", p)
+				}
+			}
+
+			// Nothing can be written next to dest folder.
+			entries, err := os.ReadDir(filepath.Dir(dest))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, e := range entries {
+				if e.Name() != filepath.Base(dest) {
+					t.Errorf("unexpected file outside of dest folder: %s", e.Name())
+				}
+			}
+		})
+	}
+}
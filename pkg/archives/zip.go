@@ -0,0 +1,68 @@
+package archives
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+func ExtractZip(src string, dest string, permissions os.FileMode) error {
+	if err := os.MkdirAll(dest, permissions); err != nil && !errors.Is(err, os.ErrExist) {
+		return fmt.Errorf("error creating folder for downloads: %w", err)
+	}
+
+	zipReader, err := zip.OpenReader(src)
+	if err != nil {
+		return fmt.Errorf("error opening archive file: %w", err)
+	}
+
+	defer func(r *zip.ReadCloser) {
+		_ = r.Close()
+	}(zipReader)
+
+	for _, f := range zipReader.File {
+		if err := extractZipEntry(f, dest, permissions); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func extractZipEntry(f *zip.File, dest string, permissions os.FileMode) error {
+	entryPath, err := sanitizeExtractPath(dest, f.Name)
+	if err != nil {
+		return err
+	}
+
+	if f.FileInfo().IsDir() {
+		if err := os.MkdirAll(entryPath, permissions); err != nil {
+			return fmt.Errorf("error creating folder: %w", err)
+		}
+
+		return nil
+	}
+
+	if !f.Mode().IsRegular() {
+		return fmt.Errorf("unexpected mode '%v' when extracting file '%s' in zip archive", f.Mode(), f.Name)
+	}
+
+	// Zip archives may not contain entries for parent folders.
+	if err := os.MkdirAll(filepath.Dir(entryPath), permissions); err != nil {
+		return fmt.Errorf("error creating folder: %w", err)
+	}
+
+	rc, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("error opening file '%s' in zip archive: %w", f.Name, err)
+	}
+
+	defer func(rc io.ReadCloser) {
+		_ = rc.Close()
+	}(rc)
+
+	return extractFile(rc, entryPath)
+}
@@ -0,0 +1,160 @@
+package archives
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type zipEntry struct {
+	name    string
+	mode    os.FileMode
+	content string
+}
+
+func writeZip(t *testing.T, path string, entries []zipEntry) {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	w := zip.NewWriter(&buf)
+
+	for _, e := range entries {
+		h := &zip.FileHeader{Name: e.name, Method: zip.Deflate} //nolint:exhaustivestruct
+		h.SetMode(e.mode)
+
+		f, err := w.CreateHeader(h)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := f.Write([]byte(e.content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExtractZip(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		entries []zipEntry
+		wantErr bool
+		// exists are paths relative to dest that must exist after extraction.
+		exists []string
+		// missing are paths relative to dest that must not exist after extraction.
+		missing []string
+	}{
+		{
+			name: "files and folders",
+			entries: []zipEntry{
+				{name: "tool/", mode: os.ModeDir | 0o755},
+				{name: "tool/tool", mode: 0o755, content: "binary"},
+				{name: "docs/README.md", mode: 0o644, content: "readme"},
+			},
+			exists: []string{"tool/tool", "docs/README.md"},
+		},
+		{
+			name: "relative path outside",
+			entries: []zipEntry{
+				{name: "../evil", mode: 0o644, content: "evil"},
+			},
+			wantErr: true,
+			missing: []string{"../evil"},
+		},
+		{
+			name: "nested relative path outside",
+			entries: []zipEntry{
+				{name: "tool/../../evil", mode: 0o644, content: "evil"},
+			},
+			wantErr: true,
+			missing: []string{"../evil"},
+		},
+		{
+			name: "absolute path is extracted inside",
+			entries: []zipEntry{
+				{name: "/tmp/evil", mode: 0o644, content: "evil"},
+			},
+			exists: []string{"tmp/evil"},
+		},
+		{
+			name: "symlink",
+			entries: []zipEntry{
+				{name: "link", mode: os.ModeSymlink | 0o777, content: "/etc/passwd"},
+			},
+			wantErr: true,
+			missing: []string{"link"},
+		},
+		{
+			name: "symlink followed by file written through it",
+			entries: []zipEntry{
+				{name: "link", mode: os.ModeSymlink | 0o777, content: ".."},
+				{name: "link/evil", mode: 0o644, content: "evil"},
+			},
+			wantErr: true,
+			missing: []string{"link", "../evil"},
+		},
+		{
+			name: "named pipe",
+			entries: []zipEntry{
+				{name: "pipe", mode: os.ModeNamedPipe | 0o644},
+			},
+			wantErr: true,
+			missing: []string{"pipe"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			root := t.TempDir()
+			src := filepath.Join(root, "archive.zip")
+			dest := filepath.Join(root, "staging", "package")
+
+			writeZip(t, src, tt.entries)
+
+			err := ExtractZip(src, dest, 0o755)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ExtractZip() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			for _, p := range tt.exists {
+				if _, err := os.Lstat(filepath.Join(dest, p)); err != nil {
+					t.Errorf("expected '%s' to exist: %v", p, err)
+				}
+			}
+
+			for _, p := range tt.missing {
+				if _, err := os.Lstat(filepath.Join(dest, p)); err == nil {
+					t.Errorf("expected '%s' to be missing", p)
+				}
+			}
+
+			// Nothing can be written next to dest folder.
+			entries, err := os.ReadDir(filepath.Dir(dest))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, e := range entries {
+				if e.Name() != filepath.Base(dest) {
+					t.Errorf("unexpected file outside of dest folder: %s", e.Name())
+				}
+			}
+		})
+	}
+}
@@ -24,15 +24,9 @@ func (d Downloader) Download(
 	asset *github.ReleaseAsset,
 	dest string,
 ) error {
-	rc, _, err := d.client.Repositories.DownloadReleaseAsset(
-		ctx,
-		repo.GetOwner().GetLogin(),
-		repo.GetName(),
-		asset.GetID(),
-		http.DefaultClient,
-	)
+	rc, err := d.open(ctx, repo, asset)
 	if err != nil {
-		return fmt.Errorf("error downloading release asset: %w", err)
+		return err
 	}
 
 	defer func(rc io.ReadCloser) {
@@ -54,3 +48,43 @@ func (d Downloader) Download(
 
 	return nil
 }
+
+// Read downloads asset contents to memory. Only first limit bytes are read,
+// so it should be used for small files like checksums and signatures.
+func (d Downloader) Read(
+	ctx context.Context,
+	repo *github.Repository,
+	asset *github.ReleaseAsset,
+	limit int64,
+) ([]byte, error) {
+	rc, err := d.open(ctx, repo, asset)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func(rc io.ReadCloser) {
+		_ = rc.Close()
+	}(rc)
+
+	b, err := io.ReadAll(io.LimitReader(rc, limit))
+	if err != nil {
+		return nil, fmt.Errorf("error reading release asset: %w", err)
+	}
+
+	return b, nil
+}
+
+func (d Downloader) open(ctx context.Context, repo *github.Repository, asset *github.ReleaseAsset) (io.ReadCloser, error) {
+	rc, _, err := d.client.Repositories.DownloadReleaseAsset(
+		ctx,
+		repo.GetOwner().GetLogin(),
+		repo.GetName(),
+		asset.GetID(),
+		http.DefaultClient,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error downloading release asset: %w", err)
+	}
+
+	return rc, nil
+}
@@ -2,7 +2,9 @@ package assets
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
+	"unicode"
 
 	"github.com/google/go-github/v39/github"
 )
@@ -22,90 +24,125 @@ const (
 	ArchX64     = Arch("x64")
 	ArchPPC64   = Arch("ppc64")
 	ArchPPC64LE = Arch("ppc64le")
+
+	LibcGlibc = Libc("glibc")
+	LibcMusl  = Libc("musl")
+)
+
+//nolint:gochecknoglobals
+var (
+	// x64Pattern matches x64 arch names containing separators.
+	x64Pattern = regexp.MustCompile(`x86[_-]64`)
+	osTokens   = map[string]OS{
+		"linux":   OSLinux,
+		"linux32": OSLinux,
+		"linux64": OSLinux,
+		"darwin":  OSMac,
+		"mac":     OSMac,
+		"macos":   OSMac,
+		"macosx":  OSMac,
+		"osx":     OSMac,
+		"apple":   OSMac,
+		"windows": OSWindows,
+		"win":     OSWindows,
+		"win32":   OSWindows,
+		"win64":   OSWindows,
+	}
+	archTokens = map[string]Arch{
+		"amd64":   ArchX64,
+		"x64":     ArchX64,
+		"64bit":   ArchX64,
+		"linux64": ArchX64,
+		"win64":   ArchX64,
+		"386":     ArchX86,
+		"i386":    ArchX86,
+		"i686":    ArchX86,
+		"x86":     ArchX86,
+		"32bit":   ArchX86,
+		"linux32": ArchX86,
+		"arm64":   ArchARM64,
+		"aarch64": ArchARM64,
+		"armv8":   ArchARM64,
+		"arm":     ArchARM86,
+		"armv5":   ArchARM86,
+		"armv6":   ArchARM86,
+		"armv6l":  ArchARM86,
+		"armv7":   ArchARM86,
+		"armv7l":  ArchARM86,
+		"armhf":   ArchARM86,
+		"armel":   ArchARM86,
+		"ppc64":   ArchPPC64,
+		"ppc64le": ArchPPC64LE,
+	}
 )
 
 type OS string
 
 type Arch string
 
+// Libc is a C standard library used by Linux distribution.
+type Libc string
+
 type Platform struct {
 	OS
 	Arch
 }
 
-type assetMetadata struct {
-	*github.ReleaseAsset
-	Platform
-}
-
-func ForPlatform(assets []*github.ReleaseAsset, platforms []Platform) (*github.ReleaseAsset, error) {
-	metadata := make([]assetMetadata, 0, len(assets))
+// ForPlatform returns asset with the best score for platforms and libc. See Rank for details.
+func ForPlatform(assets []*github.ReleaseAsset, platforms []Platform, libc Libc) (*github.ReleaseAsset, error) {
+	ranked := Rank(assets, platforms, libc)
+	if len(ranked) == 0 || ranked[0].Excluded {
+		return nil, fmt.Errorf("no assets available for this platform and arch")
+	}
 
-	for _, a := range assets {
-		name := strings.ToLower(a.GetName())
+	return ranked[0].Asset, nil
+}
 
-		m := assetMetadata{
-			ReleaseAsset: a,
-			Platform: Platform{
-				OS:   selectOS(name),
-				Arch: selectArch(name),
-			},
-		}
+// DetectPlatform detects OS and arch of the asset using its name.
+func DetectPlatform(name string) Platform {
+	tokens := tokenize(name)
 
-		metadata = append(metadata, m)
+	return Platform{
+		OS:   selectOS(name, tokens),
+		Arch: selectArch(tokens),
 	}
+}
 
-	var filtered []assetMetadata
-
-	for _, p := range platforms {
-		for _, m := range metadata {
-			if p.OS != OSAny && p.OS != m.OS {
-				continue
-			}
-
-			if p.Arch != ArchAny && p.Arch != m.Arch {
-				continue
-			}
+// tokenize splits lowercase name into tokens on separators (e. g. "tool_1.0_linux-x86_64.tar.gz" ->
+// ["tool", "1", "0", "linux", "amd64", "tar", "gz"]). Arch names containing separators are replaced with aliases.
+func tokenize(name string) []string {
+	name = strings.ToLower(name)
+	name = x64Pattern.ReplaceAllString(name, "amd64")
 
-			filtered = append(filtered, m)
-		}
+	return strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
 
-		if len(filtered) > 0 {
-			return filtered[0].ReleaseAsset, nil
+func selectArch(tokens []string) Arch {
+	for _, token := range tokens {
+		if arch, ok := archTokens[token]; ok {
+			return arch
 		}
 	}
 
-	return nil, fmt.Errorf("no assets available for this platform and arch")
+	return ArchUnknown
 }
 
-func selectArch(name string) Arch {
-	switch {
-	case strings.Contains(name, "arm64"):
-		return ArchARM64
-	case strings.Contains(name, "arm"):
-		return ArchARM86
-	case strings.Contains(name, "ppc64le"):
-		return ArchPPC64LE
-	case strings.Contains(name, "ppc64"):
-		return ArchPPC64
-	case strings.Contains(name, "x64"), strings.Contains(name, "x86_64"),
-		strings.Contains(name, "x86-64"), strings.Contains(name, "amd64"):
-		return ArchX64
-	case strings.Contains(name, "x86"):
-		return ArchX86
+func selectOS(name string, tokens []string) OS {
+	for _, token := range tokens {
+		if os, ok := osTokens[token]; ok {
+			return os
+		}
 	}
 
-	return ArchUnknown
-}
+	lower := strings.ToLower(name)
 
-func selectOS(name string) OS {
 	switch {
-	case strings.Contains(name, "linux"):
-		return OSLinux
-	case strings.Contains(name, "mac"), strings.Contains(name, "osx"), strings.Contains(name, "darwin"):
-		return OSMac
-	case strings.Contains(name, "win"):
+	case strings.HasSuffix(lower, ".exe"):
 		return OSWindows
+	case strings.HasSuffix(lower, ".deb"), strings.HasSuffix(lower, ".rpm"), strings.HasSuffix(lower, ".appimage"):
+		return OSLinux
 	}
 
 	return OSUnknown
@@ -0,0 +1,128 @@
+package assets
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/go-github/v39/github"
+)
+
+// regexpPattern matches patterns that use regular expression syntax not available in globs.
+var regexpPattern = regexp.MustCompile(`[\\^$()|+{]|\.[*+?]`) //nolint:gochecknoglobals
+
+// Pattern matches the whole asset name using glob (e. g. "*_linux_amd64.tar.gz")
+// or regular expression (e. g. "tool_.*_linux_amd64\.tar\.gz").
+type Pattern struct {
+	value string
+	re    *regexp.Regexp
+}
+
+// ParsePattern parses asset name pattern. Pattern is treated as regular expression
+// if it contains regular expression syntax (e. g. "\.", ".*" or "(a|b)"), and as glob otherwise.
+// Globs are case-insensitive.
+func ParsePattern(s string) (Pattern, error) {
+	if s == "" {
+		return Pattern{}, fmt.Errorf("asset pattern is empty")
+	}
+
+	expr := globToRegexp(s)
+	if regexpPattern.MatchString(s) {
+		expr = "^(?:" + s + ")$"
+	}
+
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return Pattern{}, fmt.Errorf("error parsing asset pattern '%s': %w", s, err)
+	}
+
+	return Pattern{value: s, re: re}, nil
+}
+
+// ExactPattern returns pattern that matches only the asset with the name.
+func ExactPattern(name string) string {
+	return regexp.QuoteMeta(name)
+}
+
+// NamePattern returns pattern that matches the asset with the name in other releases.
+// Version from the tag is replaced with wildcard (e. g. "tool_1.2.3_linux.tar.gz" -> "tool_.+_linux\.tar\.gz").
+func NamePattern(name string, tag string) string {
+	for _, version := range []string{tag, strings.TrimPrefix(tag, "v")} {
+		if version == "" || !strings.Contains(name, version) {
+			continue
+		}
+
+		parts := strings.Split(name, version)
+		for i, part := range parts {
+			parts[i] = regexp.QuoteMeta(part)
+		}
+
+		return strings.Join(parts, ".+")
+	}
+
+	return ExactPattern(name)
+}
+
+// Matches reports whether the whole asset name matches pattern.
+func (p Pattern) Matches(name string) bool {
+	return p.re != nil && p.re.MatchString(name)
+}
+
+func (p Pattern) String() string {
+	return p.value
+}
+
+// ForPattern returns asset matching the pattern.
+// If several assets match it, the best one for platforms and libc is returned.
+func ForPattern(
+	assets []*github.ReleaseAsset,
+	pattern Pattern,
+	platforms []Platform,
+	libc Libc,
+) (*github.ReleaseAsset, error) {
+	var matched []*github.ReleaseAsset
+
+	for _, a := range assets {
+		if pattern.Matches(a.GetName()) {
+			matched = append(matched, a)
+		}
+	}
+
+	switch len(matched) {
+	case 0:
+		return nil, fmt.Errorf("no assets match pattern '%s'", pattern)
+	case 1:
+		return matched[0], nil
+	}
+
+	// Any matching asset is better than none, even if its platform can't be detected.
+	platforms = append(platforms[:len(platforms):len(platforms)], Platform{OS: OSAny, Arch: ArchAny})
+
+	asset, err := ForPlatform(matched, platforms, libc)
+	if err != nil {
+		return nil, fmt.Errorf("no assets match pattern '%s' for this platform and arch: %w", pattern, err)
+	}
+
+	return asset, nil
+}
+
+func globToRegexp(glob string) string {
+	var sb strings.Builder
+
+	sb.WriteString("(?i)^")
+
+	for _, r := range glob {
+		switch r {
+		case '*':
+			sb.WriteString(".*")
+		case '?':
+			sb.WriteString(".")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+
+	sb.WriteString("$")
+
+	return sb.String()
+}
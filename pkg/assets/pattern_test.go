@@ -0,0 +1,199 @@
+package assets_test
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v39/github"
+	"github.com/iskorotkov/package-manager-cli/pkg/assets"
+)
+
+func TestParsePattern(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		pattern string
+		matches []string
+		skips   []string
+		wantErr bool
+	}{
+		{
+			pattern: "*_linux_amd64.tar.gz",
+			matches: []string{"tool_1.0_linux_amd64.tar.gz", "TOOL_1.0_LINUX_AMD64.TAR.GZ"},
+			// Dot in glob is a literal dot.
+			skips: []string{"tool_1.0_linux_amd64.tar.gz.sha256", "tool_1.0_linux_amd64_tar_gz"},
+		},
+		{
+			pattern: "tool-?.tar.gz",
+			matches: []string{"tool-1.tar.gz"},
+			skips:   []string{"tool-10.tar.gz"},
+		},
+		{
+			pattern: "[abc].tar.gz",
+			matches: []string{"[abc].tar.gz"},
+			skips:   []string{"a.tar.gz"},
+		},
+		{
+			pattern: `tool_.*_linux_amd64\.tar\.gz`,
+			matches: []string{"tool_1.0_linux_amd64.tar.gz"},
+			// Regular expressions are case-sensitive and match the whole name.
+			skips: []string{
+				"TOOL_1.0_LINUX_AMD64.TAR.GZ",
+				"tool_1.0_linux_amd64.tar.gz.sha256",
+				"my-tool_1.0_linux_amd64.tar.gz",
+			},
+		},
+		{
+			pattern: "tool-(cli|server).zip",
+			matches: []string{"tool-cli.zip", "tool-server.zip"},
+			skips:   []string{"tool-cuda.zip"},
+		},
+		{pattern: "^tool.+$", matches: []string{"tool-linux"}, skips: []string{"tool"}},
+		{pattern: "tool_(.*", wantErr: true},
+		{pattern: `tool\`, wantErr: true},
+		{pattern: "tool{2,1}", wantErr: true},
+		{pattern: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.pattern, func(t *testing.T) {
+			t.Parallel()
+
+			p, err := assets.ParsePattern(tt.pattern)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePattern(%q) error = %v, wantErr %v", tt.pattern, err, tt.wantErr)
+			}
+
+			for _, name := range tt.matches {
+				if !p.Matches(name) {
+					t.Errorf("pattern %q doesn't match %q", tt.pattern, name)
+				}
+			}
+
+			for _, name := range tt.skips {
+				if p.Matches(name) {
+					t.Errorf("pattern %q matches %q", tt.pattern, name)
+				}
+			}
+		})
+	}
+}
+
+func TestNamePattern(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		tag  string
+		want string
+		// next is an asset name of another release that must match the pattern.
+		next string
+	}{
+		{
+			name: "k9s_v0.25.1_Linux_x86_64.tar.gz",
+			tag:  "v0.25.1",
+			want: `k9s_.+_Linux_x86_64\.tar\.gz`,
+			next: "k9s_v0.26.0_Linux_x86_64.tar.gz",
+		},
+		{
+			name: "ripgrep-13.0.0-x86_64-unknown-linux-musl.tar.gz",
+			tag:  "13.0.0",
+			want: `ripgrep-.+-x86_64-unknown-linux-musl\.tar\.gz`,
+			next: "ripgrep-14.1.0-x86_64-unknown-linux-musl.tar.gz",
+		},
+		{
+			name: "tool_1.2.3_linux.tar.gz",
+			tag:  "v1.2.3",
+			want: `tool_.+_linux\.tar\.gz`,
+			next: "tool_1.3.0_linux.tar.gz",
+		},
+		{
+			name: "tool-1.0-src-1.0.zip",
+			tag:  "1.0",
+			want: `tool-.+-src-.+\.zip`,
+			next: "tool-2.0-src-2.0.zip",
+		},
+		{
+			name: "tool-linux-amd64",
+			tag:  "v1.0.0",
+			want: `tool-linux-amd64`,
+			next: "tool-linux-amd64",
+		},
+		{
+			name: "tool+cuda.tar.gz",
+			tag:  "",
+			want: `tool\+cuda\.tar\.gz`,
+			next: "tool+cuda.tar.gz",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := assets.NamePattern(tt.name, tt.tag)
+			if got != tt.want {
+				t.Fatalf("NamePattern() = %s, want %s", got, tt.want)
+			}
+
+			p, err := assets.ParsePattern(got)
+			if err != nil {
+				t.Fatalf("ParsePattern() error = %v", err)
+			}
+
+			if !p.Matches(tt.name) || !p.Matches(tt.next) {
+				t.Errorf("pattern %s doesn't match %s or %s", got, tt.name, tt.next)
+			}
+		})
+	}
+}
+
+func TestForPattern(t *testing.T) {
+	t.Parallel()
+
+	names := []string{"tool-cli_linux_amd64.tar.gz", "tool-cli_linux_arm64.tar.gz", "tool-server_linux_amd64.tar.gz"}
+
+	releaseAssets := make([]*github.ReleaseAsset, 0, len(names))
+	for _, name := range names {
+		releaseAssets = append(releaseAssets, &github.ReleaseAsset{Name: github.String(name)}) //nolint:exhaustivestruct
+	}
+
+	platforms, err := assets.Platforms("linux", "arm64")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := assets.ParsePattern("tool-cli_*")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Platform is used to select one of assets matching the pattern.
+	got, err := assets.ForPattern(releaseAssets, p, platforms, assets.LibcGlibc)
+	if err != nil || got.GetName() != "tool-cli_linux_arm64.tar.gz" {
+		t.Errorf("ForPattern() = %s, %v, want tool-cli_linux_arm64.tar.gz", got.GetName(), err)
+	}
+
+	p, err = assets.ParsePattern("tool-server_*")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The only matching asset is selected even if it's for another platform.
+	got, err = assets.ForPattern(releaseAssets, p, platforms, assets.LibcGlibc)
+	if err != nil || got.GetName() != "tool-server_linux_amd64.tar.gz" {
+		t.Errorf("ForPattern() = %s, %v, want tool-server_linux_amd64.tar.gz", got.GetName(), err)
+	}
+
+	p, err = assets.ParsePattern("*.deb")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := assets.ForPattern(releaseAssets, p, platforms, assets.LibcGlibc); err == nil {
+		t.Errorf("ForPattern() error = nil, want error")
+	}
+}
@@ -0,0 +1,131 @@
+package assets
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// HostPlatforms returns platforms that can run on the current host from the most to the least preferred.
+func HostPlatforms() []Platform {
+	platforms, err := Platforms(runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		// Assets with unknown platform can still be used on unsupported hosts.
+		return []Platform{{OS: OSUnknown, Arch: ArchUnknown}}
+	}
+
+	return platforms
+}
+
+// Platforms returns platforms that can run on the OS and arch from the most to the least preferred.
+// OS and arch can be set using Go names (e. g. "darwin" and "amd64") or common aliases (e. g. "macos" and "x86_64").
+func Platforms(os string, arch string) ([]Platform, error) {
+	o, err := ParseOS(os)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := ParseArch(arch)
+	if err != nil {
+		return nil, err
+	}
+
+	compatible, lastResort := fallbackArchs(o, a)
+
+	platforms := []Platform{{OS: o, Arch: a}}
+
+	for _, fallback := range compatible {
+		platforms = append(platforms, Platform{OS: o, Arch: fallback})
+	}
+
+	platforms = append(platforms,
+		Platform{OS: o, Arch: ArchUnknown},
+		Platform{OS: OSUnknown, Arch: a},
+	)
+
+	for _, fallback := range lastResort {
+		platforms = append(platforms, Platform{OS: o, Arch: fallback})
+	}
+
+	return append(platforms, Platform{OS: OSUnknown, Arch: ArchUnknown}), nil
+}
+
+// HostLibc returns libc of the current host. Linux hosts with musl (e. g. Alpine) use musl, other hosts use glibc.
+func HostLibc() Libc {
+	if runtime.GOOS != "linux" {
+		return LibcGlibc
+	}
+
+	if matches, _ := filepath.Glob("/lib/ld-musl-*.so.1"); len(matches) > 0 {
+		return LibcMusl
+	}
+
+	if _, err := os.Stat("/etc/alpine-release"); err == nil {
+		return LibcMusl
+	}
+
+	return LibcGlibc
+}
+
+// ParseLibc parses libc name.
+func ParseLibc(s string) (Libc, error) {
+	switch strings.ToLower(s) {
+	case "glibc", "gnu":
+		return LibcGlibc, nil
+	case "musl":
+		return LibcMusl, nil
+	}
+
+	return "", fmt.Errorf("unsupported libc '%s', supported values are glibc and musl", s)
+}
+
+// ParseOS parses OS name.
+func ParseOS(s string) (OS, error) {
+	switch strings.ToLower(s) {
+	case "linux":
+		return OSLinux, nil
+	case "darwin", "mac", "macos", "osx":
+		return OSMac, nil
+	case "windows", "win":
+		return OSWindows, nil
+	}
+
+	return "", fmt.Errorf("unsupported OS '%s', supported values are linux, darwin and windows", s)
+}
+
+// ParseArch parses arch name.
+func ParseArch(s string) (Arch, error) {
+	switch strings.ToLower(s) {
+	case "amd64", "x64", "x86_64", "x86-64":
+		return ArchX64, nil
+	case "386", "x86", "i386", "i686":
+		return ArchX86, nil
+	case "arm64", "aarch64":
+		return ArchARM64, nil
+	case "arm", "armv6", "armv7":
+		return ArchARM86, nil
+	case "ppc64":
+		return ArchPPC64, nil
+	case "ppc64le":
+		return ArchPPC64LE, nil
+	}
+
+	return "", fmt.Errorf("unsupported arch '%s', supported values are amd64, 386, arm64, arm, ppc64 and ppc64le", s)
+}
+
+// fallbackArchs returns archs which binaries can run on the OS and arch.
+// Compatible archs are preferred to assets with unknown arch, and last resort archs are used only if nothing else fits.
+// ARM64 hosts don't fall back to 32-bit ARM, as many of them can't run it.
+func fallbackArchs(os OS, arch Arch) (compatible []Arch, lastResort []Arch) {
+	switch {
+	case os == OSMac && arch == ArchARM64:
+		// Apple Silicon runs x64 binaries using Rosetta.
+		return []Arch{ArchX64}, nil
+	case arch == ArchX64:
+		return nil, []Arch{ArchX86}
+	}
+
+	return nil, nil
+}
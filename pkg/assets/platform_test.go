@@ -0,0 +1,142 @@
+package assets_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/iskorotkov/package-manager-cli/pkg/assets"
+)
+
+func TestPlatforms(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		os      string
+		arch    string
+		want    []assets.Platform
+		wantErr bool
+	}{
+		{
+			os:   "linux",
+			arch: "amd64",
+			want: []assets.Platform{
+				{OS: assets.OSLinux, Arch: assets.ArchX64},
+				{OS: assets.OSLinux, Arch: assets.ArchUnknown},
+				{OS: assets.OSUnknown, Arch: assets.ArchX64},
+				{OS: assets.OSLinux, Arch: assets.ArchX86},
+				{OS: assets.OSUnknown, Arch: assets.ArchUnknown},
+			},
+		},
+		{
+			os:   "linux",
+			arch: "arm64",
+			// ARM64 hosts don't fall back to 32-bit ARM.
+			want: []assets.Platform{
+				{OS: assets.OSLinux, Arch: assets.ArchARM64},
+				{OS: assets.OSLinux, Arch: assets.ArchUnknown},
+				{OS: assets.OSUnknown, Arch: assets.ArchARM64},
+				{OS: assets.OSUnknown, Arch: assets.ArchUnknown},
+			},
+		},
+		{
+			os:   "macos",
+			arch: "aarch64",
+			// Apple Silicon runs x64 binaries using Rosetta.
+			want: []assets.Platform{
+				{OS: assets.OSMac, Arch: assets.ArchARM64},
+				{OS: assets.OSMac, Arch: assets.ArchX64},
+				{OS: assets.OSMac, Arch: assets.ArchUnknown},
+				{OS: assets.OSUnknown, Arch: assets.ArchARM64},
+				{OS: assets.OSUnknown, Arch: assets.ArchUnknown},
+			},
+		},
+		{
+			os:   "windows",
+			arch: "386",
+			want: []assets.Platform{
+				{OS: assets.OSWindows, Arch: assets.ArchX86},
+				{OS: assets.OSWindows, Arch: assets.ArchUnknown},
+				{OS: assets.OSUnknown, Arch: assets.ArchX86},
+				{OS: assets.OSUnknown, Arch: assets.ArchUnknown},
+			},
+		},
+		{os: "plan9", arch: "amd64", wantErr: true},
+		{os: "linux", arch: "mips", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.os+"/"+tt.arch, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := assets.Platforms(tt.os, tt.arch)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Platforms() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Platforms() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHostPlatforms(t *testing.T) {
+	t.Parallel()
+
+	platforms := assets.HostPlatforms()
+	if len(platforms) == 0 {
+		t.Fatal("HostPlatforms() returned no platforms")
+	}
+
+	// Assets with unknown platform can be used on any host.
+	if last := platforms[len(platforms)-1]; last != (assets.Platform{OS: assets.OSUnknown, Arch: assets.ArchUnknown}) {
+		t.Errorf("HostPlatforms() last platform = %+v, want unknown platform", last)
+	}
+}
+
+func TestDetectPlatform(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		want assets.Platform
+	}{
+		{name: "k9s_Linux_x86_64.tar.gz", want: assets.Platform{OS: assets.OSLinux, Arch: assets.ArchX64}},
+		{name: "ripgrep-13.0.0-aarch64-apple-darwin.tar.gz", want: assets.Platform{OS: assets.OSMac, Arch: assets.ArchARM64}},
+		{name: "tool_windows_386.zip", want: assets.Platform{OS: assets.OSWindows, Arch: assets.ArchX86}},
+		{name: "tool-linux-armv7.tar.gz", want: assets.Platform{OS: assets.OSLinux, Arch: assets.ArchARM86}},
+		{name: "tool.exe", want: assets.Platform{OS: assets.OSWindows, Arch: assets.ArchUnknown}},
+		{name: "tool_1.0_amd64.deb", want: assets.Platform{OS: assets.OSLinux, Arch: assets.ArchX64}},
+		{name: "tool.tar.gz", want: assets.Platform{OS: assets.OSUnknown, Arch: assets.ArchUnknown}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := assets.DetectPlatform(tt.name); got != tt.want {
+				t.Errorf("DetectPlatform() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseLibc(t *testing.T) {
+	t.Parallel()
+
+	valid := map[string]assets.Libc{"glibc": assets.LibcGlibc, "GNU": assets.LibcGlibc, "musl": assets.LibcMusl}
+
+	for value, want := range valid {
+		if got, err := assets.ParseLibc(value); err != nil || got != want {
+			t.Errorf("ParseLibc(%q) = %q, %v, want %q", value, got, err, want)
+		}
+	}
+
+	if _, err := assets.ParseLibc("uclibc"); err == nil {
+		t.Errorf("ParseLibc() error = nil, want error")
+	}
+}
@@ -0,0 +1,241 @@
+package assets
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v39/github"
+	"github.com/iskorotkov/package-manager-cli/pkg/archives"
+	"github.com/iskorotkov/package-manager-cli/pkg/checksums"
+	"github.com/iskorotkov/package-manager-cli/pkg/signatures"
+)
+
+const (
+	// platformPoints are given for each platform after the matching one, so platform preference is the most important.
+	platformPoints = 100
+
+	archivePoints       = 30
+	compressedPoints    = 25
+	binaryPoints        = 20
+	appImagePoints      = 15
+	systemPackagePoints = 10
+
+	preferredLibcPoints = 5
+	staticPoints        = 4
+	muslPoints          = 3
+)
+
+//nolint:gochecknoglobals
+var (
+	// excludedSuffixes are extensions of files that can't be installed.
+	excludedSuffixes = []struct {
+		ext    string
+		reason string
+	}{
+		{".sbom", "SBOM"},
+		{".sbom.json", "SBOM"},
+		{".spdx", "SBOM"},
+		{".spdx.json", "SBOM"},
+		{".cdx.json", "SBOM"},
+		{".bom.json", "SBOM"},
+		{".intoto.jsonl", "attestation"},
+		{".pem", "certificate"},
+		{".crt", "certificate"},
+		{".cert", "certificate"},
+		{".pub", "public key"},
+		{".md5", "checksum"},
+		{".sha1", "checksum"},
+		{".sha512", "checksum"},
+		{".sha512sum", "checksum"},
+		{".txt", "text file"},
+		{".md", "text file"},
+		{".json", "metadata file"},
+		{".yaml", "metadata file"},
+		{".yml", "metadata file"},
+		{".dmg", "unsupported installer"},
+		{".pkg", "unsupported installer"},
+		{".msi", "unsupported installer"},
+		{".apk", "unsupported package"},
+		{".snap", "unsupported package"},
+		{".flatpak", "unsupported package"},
+		{".vsix", "unsupported package"},
+	}
+	sourceTokens = map[string]bool{"src": true, "source": true, "sources": true}
+)
+
+// Score is a score of the release asset with reasons explaining it.
+type Score struct {
+	Asset *github.ReleaseAsset
+	// Platform is a platform detected using asset name.
+	Platform Platform
+	Points   int
+	// Excluded is set if asset can't be installed on any of the platforms.
+	Excluded bool
+	Reasons  []string
+}
+
+func (s *Score) add(points int, reason string) {
+	s.Points += points
+	s.Reasons = append(s.Reasons, fmt.Sprintf("%+d %s", points, reason))
+}
+
+func (s *Score) exclude(reason string) {
+	s.Excluded = true
+	s.Reasons = append(s.Reasons, "excluded: "+reason)
+}
+
+// Rank scores assets and returns them from the best to the worst. Excluded assets go last.
+// Assets are scored by (from the most to the least important):
+// - platform: assets for more preferred platforms are better;
+// - format: archives are better than compressed binaries, binaries, AppImages and deb/rpm packages;
+// - libc: builds for libc and static builds are better.
+// Checksums, signatures, SBOMs, source code and files that can't be installed are excluded.
+func Rank(assets []*github.ReleaseAsset, platforms []Platform, libc Libc) []Score {
+	scores := make([]Score, 0, len(assets))
+
+	for _, a := range assets {
+		scores = append(scores, score(a, platforms, libc))
+	}
+
+	sort.SliceStable(scores, func(i, j int) bool {
+		if scores[i].Excluded != scores[j].Excluded {
+			return !scores[i].Excluded
+		}
+
+		return scores[i].Points > scores[j].Points
+	})
+
+	return scores
+}
+
+// IsAmbiguous reports whether the best asset can't be selected using ranked scores,
+// i. e. there are no installable assets or several assets have the best score.
+func IsAmbiguous(ranked []Score) bool {
+	if len(ranked) == 0 || ranked[0].Excluded {
+		return true
+	}
+
+	return len(ranked) > 1 && !ranked[1].Excluded && ranked[1].Points == ranked[0].Points
+}
+
+func score(a *github.ReleaseAsset, platforms []Platform, libc Libc) Score {
+	name := a.GetName()
+	tokens := tokenize(name)
+
+	s := Score{ //nolint:exhaustivestruct
+		Asset:    a,
+		Platform: DetectPlatform(name),
+	}
+
+	if reason := excludeReason(name, tokens); reason != "" {
+		s.exclude(reason)
+
+		return s
+	}
+
+	index := platformIndex(s.Platform, platforms)
+	if index < 0 {
+		s.exclude(fmt.Sprintf("platform %s/%s doesn't match", s.Platform.OS, s.Platform.Arch))
+
+		return s
+	}
+
+	s.add((len(platforms)-index)*platformPoints,
+		fmt.Sprintf("platform %s/%s (preference #%d)", platforms[index].OS, platforms[index].Arch, index+1))
+
+	scoreFormat(&s, name)
+
+	if s.Platform.OS == OSLinux || s.Platform.OS == OSUnknown {
+		scoreLibc(&s, tokens, libc)
+	}
+
+	return s
+}
+
+func excludeReason(name string, tokens []string) string {
+	lower := strings.ToLower(name)
+
+	switch {
+	case checksums.IsChecksumFile(name):
+		return "checksum"
+	case signatures.IsSignatureFile(name):
+		return "signature"
+	}
+
+	for _, suffix := range excludedSuffixes {
+		if strings.HasSuffix(lower, suffix.ext) {
+			return suffix.reason
+		}
+	}
+
+	for _, token := range tokens {
+		if sourceTokens[token] {
+			return "source code"
+		}
+	}
+
+	return ""
+}
+
+// platformIndex returns index of the first platform matching asset platform or -1 if none match.
+func platformIndex(assetPlatform Platform, platforms []Platform) int {
+	for i, p := range platforms {
+		if p.OS != OSAny && p.OS != assetPlatform.OS {
+			continue
+		}
+
+		if p.Arch != ArchAny && p.Arch != assetPlatform.Arch {
+			continue
+		}
+
+		return i
+	}
+
+	return -1
+}
+
+func scoreFormat(s *Score, name string) {
+	format := archives.FormatFromName(name)
+
+	switch {
+	case format.IsSystemPackage():
+		s.add(systemPackagePoints, "deb/rpm package")
+	case format.IsAppImage():
+		s.add(appImagePoints, "AppImage")
+	case format.IsArchive():
+		s.add(archivePoints, "archive")
+	case format.IsCompressed():
+		s.add(compressedPoints, "compressed binary")
+	default:
+		s.add(binaryPoints, "binary")
+	}
+}
+
+func scoreLibc(s *Score, tokens []string, libc Libc) {
+	for _, token := range tokens {
+		// ABI suffixes are ignored (e. g. "gnueabihf" or "musleabi").
+		switch {
+		case token == "static":
+			s.add(staticPoints, "static build")
+
+			return
+		case strings.HasPrefix(token, "musl"):
+			if libc == LibcMusl {
+				s.add(preferredLibcPoints, "musl build for musl target")
+			} else {
+				s.add(muslPoints, "musl build")
+			}
+
+			return
+		case strings.HasPrefix(token, "gnu"), token == "glibc":
+			if libc == LibcMusl {
+				s.exclude("glibc build can't run on musl target")
+			} else {
+				s.add(preferredLibcPoints, "glibc build for glibc target")
+			}
+
+			return
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package assets_test
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v39/github"
+	"github.com/iskorotkov/package-manager-cli/pkg/assets"
+)
+
+func TestRankLibc(t *testing.T) {
+	t.Parallel()
+
+	names := []string{"tool-x86_64-unknown-linux-gnu.tar.gz", "tool-x86_64-unknown-linux-musl.tar.gz"}
+
+	releaseAssets := make([]*github.ReleaseAsset, 0, len(names))
+	for _, name := range names {
+		releaseAssets = append(releaseAssets, &github.ReleaseAsset{Name: github.String(name)}) //nolint:exhaustivestruct
+	}
+
+	platforms, err := assets.Platforms("linux", "amd64")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		libc assets.Libc
+		want string
+	}{
+		{libc: assets.LibcGlibc, want: "tool-x86_64-unknown-linux-gnu.tar.gz"},
+		{libc: assets.LibcMusl, want: "tool-x86_64-unknown-linux-musl.tar.gz"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(string(tt.libc), func(t *testing.T) {
+			t.Parallel()
+
+			got, err := assets.ForPlatform(releaseAssets, platforms, tt.libc)
+			if err != nil {
+				t.Fatalf("ForPlatform() error = %v", err)
+			}
+
+			if got.GetName() != tt.want {
+				t.Errorf("ForPlatform() = %s, want %s", got.GetName(), tt.want)
+			}
+		})
+	}
+}
@@ -8,4 +8,6 @@ type AssetData struct {
 	Repository *github.Repository
 	Release    *github.RepositoryRelease
 	Asset      *github.ReleaseAsset
+	// Pattern is an asset pattern chosen by user. It's empty if asset was selected automatically.
+	Pattern string
 }
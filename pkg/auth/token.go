@@ -0,0 +1,153 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	SourceNone          = Source("anonymous")
+	SourcePMEnv         = Source("PM_GITHUB_TOKEN")
+	SourceGitHubEnv     = Source("GITHUB_TOKEN")
+	SourceEnterpriseEnv = Source("GH_ENTERPRISE_TOKEN")
+	SourceFile          = Source("token file")
+	SourceGitHubCLI     = Source("gh CLI")
+)
+
+const (
+	gitHubHost       = "github.com"
+	gitHubCLITimeout = 5 * time.Second
+	gitHubCLIDir     = "gh"
+)
+
+// Source is a place the token was read from.
+type Source string
+
+// Token is a GitHub API token.
+type Token struct {
+	Value  string
+	Source Source
+}
+
+// ghHost is an entry of gh CLI hosts.yml file.
+type ghHost struct {
+	OAuthToken string `yaml:"oauth_token"`
+}
+
+// Find returns token for GitHub host (e. g. "github.com") from the first available source:
+// PM_GITHUB_TOKEN and GITHUB_TOKEN environment variables, token file at tokenPath and gh CLI credentials.
+// For other hosts (GitHub Enterprise Server) only GH_ENTERPRISE_TOKEN, PM_GITHUB_TOKEN and gh CLI credentials
+// of the host are used, so github.com token isn't sent to them.
+// It returns empty token with SourceNone if no token was found.
+func Find(host string, tokenPath string) (Token, error) {
+	if host != gitHubHost {
+		return findEnterprise(host), nil
+	}
+
+	if token, ok := fromEnv(SourcePMEnv, SourceGitHubEnv); ok {
+		return token, nil
+	}
+
+	value, err := fromFile(tokenPath)
+	if err != nil {
+		return Token{}, err
+	}
+
+	if value != "" {
+		return Token{Value: value, Source: SourceFile}, nil
+	}
+
+	if value := fromGitHubCLI(host); value != "" {
+		return Token{Value: value, Source: SourceGitHubCLI}, nil
+	}
+
+	return Token{Value: "", Source: SourceNone}, nil
+}
+
+// findEnterprise returns token for GitHub Enterprise Server host.
+func findEnterprise(host string) Token {
+	if token, ok := fromEnv(SourceEnterpriseEnv, SourcePMEnv); ok {
+		return token
+	}
+
+	if value := fromGitHubCLI(host); value != "" {
+		return Token{Value: value, Source: SourceGitHubCLI}
+	}
+
+	return Token{Value: "", Source: SourceNone}
+}
+
+// fromEnv returns token from the first set environment variable.
+func fromEnv(names ...Source) (Token, bool) {
+	for _, name := range names {
+		if value := strings.TrimSpace(os.Getenv(string(name))); value != "" {
+			return Token{Value: value, Source: name}, true
+		}
+	}
+
+	return Token{}, false
+}
+
+// fromFile returns token stored in file. It returns empty token if file doesn't exist.
+func fromFile(path string) (string, error) {
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	} else if err != nil {
+		return "", fmt.Errorf("error reading token file '%s': %w", path, err)
+	}
+
+	return strings.TrimSpace(string(b)), nil
+}
+
+// fromGitHubCLI returns token stored by gh CLI. Newer versions of gh CLI keep tokens in system keyring,
+// so "gh auth token" is used if token isn't found in hosts.yml. Errors are ignored as gh CLI is optional.
+func fromGitHubCLI(host string) string {
+	if dir, err := gitHubCLIConfigDir(); err == nil {
+		if b, err := os.ReadFile(filepath.Join(dir, "hosts.yml")); err == nil {
+			var hosts map[string]ghHost
+			if err := yaml.Unmarshal(b, &hosts); err == nil && hosts[host].OAuthToken != "" {
+				return hosts[host].OAuthToken
+			}
+		}
+	}
+
+	if _, err := exec.LookPath("gh"); err != nil {
+		return ""
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), gitHubCLITimeout)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, "gh", "auth", "token", "--hostname", host).Output()
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(string(out))
+}
+
+func gitHubCLIConfigDir() (string, error) {
+	if dir := os.Getenv("GH_CONFIG_DIR"); dir != "" {
+		return dir, nil
+	}
+
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, gitHubCLIDir), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("error getting home folder: %w", err)
+	}
+
+	return filepath.Join(home, ".config", gitHubCLIDir), nil
+}
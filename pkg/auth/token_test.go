@@ -0,0 +1,106 @@
+package auth_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/iskorotkov/package-manager-cli/pkg/auth"
+)
+
+//nolint:paralleltest // Environment variables are modified.
+func TestFind(t *testing.T) {
+	tests := []struct {
+		name string
+		host string
+		env  map[string]string
+		file string
+		want auth.Token
+	}{
+		{
+			name: "github.com uses PM_GITHUB_TOKEN first",
+			host: "github.com",
+			env:  map[string]string{"PM_GITHUB_TOKEN": "pm", "GITHUB_TOKEN": "gh"},
+			want: auth.Token{Value: "pm", Source: auth.SourcePMEnv},
+		},
+		{
+			name: "github.com uses GITHUB_TOKEN",
+			host: "github.com",
+			env:  map[string]string{"GITHUB_TOKEN": "gh", "GH_ENTERPRISE_TOKEN": "enterprise"},
+			want: auth.Token{Value: "gh", Source: auth.SourceGitHubEnv},
+		},
+		{
+			name: "github.com uses token file",
+			host: "github.com",
+			file: "file\n",
+			want: auth.Token{Value: "file", Source: auth.SourceFile},
+		},
+		{
+			name: "github.com uses gh CLI hosts",
+			host: "github.com",
+			want: auth.Token{Value: "cli-github", Source: auth.SourceGitHubCLI},
+		},
+		{
+			name: "enterprise uses GH_ENTERPRISE_TOKEN first",
+			host: "github.example.com",
+			env:  map[string]string{"GH_ENTERPRISE_TOKEN": "enterprise", "PM_GITHUB_TOKEN": "pm"},
+			want: auth.Token{Value: "enterprise", Source: auth.SourceEnterpriseEnv},
+		},
+		{
+			name: "enterprise uses PM_GITHUB_TOKEN",
+			host: "github.example.com",
+			env:  map[string]string{"PM_GITHUB_TOKEN": "pm", "GITHUB_TOKEN": "gh"},
+			want: auth.Token{Value: "pm", Source: auth.SourcePMEnv},
+		},
+		{
+			name: "enterprise ignores GITHUB_TOKEN and token file",
+			host: "github.example.com",
+			env:  map[string]string{"GITHUB_TOKEN": "gh"},
+			file: "file",
+			want: auth.Token{Value: "cli-enterprise", Source: auth.SourceGitHubCLI},
+		},
+		{
+			name: "enterprise without credentials is anonymous",
+			host: "other.example.com",
+			env:  map[string]string{"GITHUB_TOKEN": "gh"},
+			file: "file",
+			want: auth.Token{Value: "", Source: auth.SourceNone},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+
+			hosts := "github.com:\n  oauth_token: cli-github\ngithub.example.com:\n  oauth_token: cli-enterprise\n"
+			if err := os.WriteFile(filepath.Join(dir, "hosts.yml"), []byte(hosts), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			// Empty PATH ensures that installed gh CLI isn't used.
+			t.Setenv("PATH", "")
+			t.Setenv("GH_CONFIG_DIR", dir)
+
+			for _, name := range []string{"PM_GITHUB_TOKEN", "GITHUB_TOKEN", "GH_ENTERPRISE_TOKEN"} {
+				t.Setenv(name, tt.env[name])
+			}
+
+			tokenPath := filepath.Join(dir, "token")
+			if tt.file != "" {
+				if err := os.WriteFile(tokenPath, []byte(tt.file), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got, err := auth.Find(tt.host, tokenPath)
+			if err != nil {
+				t.Fatalf("Find() error = %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("Find() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
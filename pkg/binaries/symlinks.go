@@ -13,25 +13,44 @@ func AddSymlinks(src, dest string, permissions os.FileMode) ([]string, error) {
 		return nil, fmt.Errorf("error creating folder '%s' for symlinks: %w", dest, err)
 	}
 
-	entries, err := os.ReadDir(src)
+	src, entries, err := packageRoot(src)
 	if err != nil {
-		return nil, fmt.Errorf("error reading contents of src folder: %w", err)
+		return nil, err
 	}
 
 	var created []string
 
 	for _, entry := range entries {
 		entrySymlinks, err := analyzeSourceEntry(src, dest, permissions, entry)
+
+		// Symlinks created before the error are returned, so they can be removed by the caller.
+		created = append(created, entrySymlinks...)
+
 		if err != nil {
 			return created, err
 		}
-
-		created = append(created, entrySymlinks...)
 	}
 
 	return created, nil
 }
 
+// packageRoot returns folder with package contents and its entries. Archives often wrap contents
+// in a single top-level folder (e. g. "ripgrep-13.0.0-x86_64-unknown-linux-musl/rg"), so such folders are descended.
+func packageRoot(src string) (string, []os.DirEntry, error) {
+	for {
+		entries, err := os.ReadDir(src)
+		if err != nil {
+			return "", nil, fmt.Errorf("error reading contents of src folder: %w", err)
+		}
+
+		if len(entries) != 1 || !entries[0].IsDir() || entries[0].Name() == "bin" {
+			return src, entries, nil
+		}
+
+		src = filepath.Join(src, entries[0].Name())
+	}
+}
+
 func analyzeSourceEntry(src string, dest string, permissions os.FileMode, entry os.DirEntry) ([]string, error) {
 	ext := filepath.Ext(entry.Name())
 	lowerName := strings.ToLower(entry.Name())
@@ -41,11 +60,12 @@ func analyzeSourceEntry(src string, dest string, permissions os.FileMode, entry
 	switch {
 	case entry.IsDir() && entry.Name() == "bin":
 		binSymlinks, err := addSymlinksToBin(src, dest, entry, permissions)
+
+		created = append(created, binSymlinks...)
+
 		if err != nil {
 			return created, err
 		}
-
-		created = append(created, binSymlinks...)
 	case entry.IsDir(), shouldSkipExtension(ext), shouldSkipFile(lowerName):
 	default:
 		src := filepath.Join(src, entry.Name())
@@ -53,6 +73,8 @@ func analyzeSourceEntry(src string, dest string, permissions os.FileMode, entry
 
 		if err := createSymlink(src, dest, permissions); errors.Is(err, os.ErrExist) {
 			printSymlinkExists(entry)
+
+			return created, nil
 		} else if err != nil {
 			return created, err
 		}
@@ -72,7 +94,7 @@ func shouldSkipFile(lowerName string) bool {
 }
 
 func addSymlinksToBin(src string, dest string, dir os.DirEntry, permissions os.FileMode) ([]string, error) {
-	binFiles, err := os.ReadDir(dir.Name())
+	binFiles, err := os.ReadDir(filepath.Join(src, dir.Name()))
 	if err != nil {
 		return nil, fmt.Errorf("error reading contents of bin folder: %w", err)
 	}
@@ -88,7 +110,9 @@ func addSymlinksToBin(src string, dest string, dir os.DirEntry, permissions os.F
 		dest := filepath.Join(dest, entry.Name())
 
 		if err := createSymlink(src, dest, permissions); errors.Is(err, os.ErrExist) {
-			printSymlinkExists(dir)
+			printSymlinkExists(entry)
+
+			continue
 		} else if err != nil {
 			return created, err
 		}
@@ -0,0 +1,121 @@
+package binaries
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestAddSymlinksReturnsPartialResultOnError(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	src := filepath.Join(root, "package")
+	dest := filepath.Join(root, "links")
+
+	if err := os.MkdirAll(filepath.Join(src, "bin"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(src, "bin", "a"), []byte("binary"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	// Dangling symlink can't be made executable, so linking it fails.
+	if err := os.Symlink(filepath.Join(root, "missing"), filepath.Join(src, "bin", "b")); err != nil {
+		t.Fatal(err)
+	}
+
+	created, err := AddSymlinks(src, dest, 0o755)
+	if err == nil {
+		t.Fatal("AddSymlinks() error = nil, want error")
+	}
+
+	want := filepath.Join(dest, "a")
+	if len(created) != 1 || created[0] != want {
+		t.Fatalf("AddSymlinks() created = %v, want [%s]", created, want)
+	}
+
+	if _, err := os.Lstat(want); err != nil {
+		t.Errorf("expected symlink '%s' to exist: %v", want, err)
+	}
+}
+
+func TestAddSymlinks(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		files []string
+		want  []string
+	}{
+		{
+			name:  "top-level files",
+			files: []string{"tool", "install.sh", "README.md", "LICENSE", "docs/tool.1"},
+			want:  []string{"install.sh", "tool"},
+		},
+		{
+			name:  "bin folder",
+			files: []string{"bin/tool", "bin/helper", "share/man/tool.1"},
+			want:  []string{"helper", "tool"},
+		},
+		{
+			name: "single top-level folder",
+			files: []string{
+				"ripgrep-13.0.0-x86_64-unknown-linux-musl/rg",
+				"ripgrep-13.0.0-x86_64-unknown-linux-musl/README.md",
+				"ripgrep-13.0.0-x86_64-unknown-linux-musl/doc/rg.1",
+				"ripgrep-13.0.0-x86_64-unknown-linux-musl/complete/rg.bash",
+			},
+			want: []string{"rg"},
+		},
+		{
+			name:  "nested top-level folders with bin",
+			files: []string{"tool-1.0/linux/bin/tool", "tool-1.0/linux/lib/libtool.so"},
+			want:  []string{"tool"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			root := t.TempDir()
+			src := filepath.Join(root, "package")
+			dest := filepath.Join(root, "links")
+
+			for _, file := range tt.files {
+				path := filepath.Join(src, filepath.FromSlash(file))
+
+				if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+					t.Fatal(err)
+				}
+
+				if err := os.WriteFile(path, []byte("binary"), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if _, err := AddSymlinks(src, dest, 0o755); err != nil {
+				t.Fatalf("AddSymlinks() error = %v", err)
+			}
+
+			entries, err := os.ReadDir(dest)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := make([]string, 0, len(entries))
+			for _, entry := range entries {
+				got = append(got, entry.Name())
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AddSymlinks() created %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package checksums
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/google/go-github/v39/github"
+)
+
+// ErrMismatch is returned when digest of the file doesn't match the expected one.
+var ErrMismatch = errors.New("checksum mismatch")
+
+// ErrNotFound is returned when checksum file doesn't contain digest for the asset.
+var ErrNotFound = errors.New("checksum not found")
+
+//nolint:gochecknoglobals
+var (
+	// bsdPattern matches lines in BSD format (e. g. "SHA256 (tool.tar.gz) = <digest>").
+	bsdPattern = regexp.MustCompile(`^SHA256 ?\((.+)\) ?= ?([0-9a-fA-F]{64})$`)
+	// digestPattern matches hex-encoded SHA-256 digest.
+	digestPattern = regexp.MustCompile(`^[0-9a-fA-F]{64}$`)
+	// sumsFilePattern matches files with digests of multiple assets (e. g. "checksums.txt" or "tool_1.0_SHA256SUMS").
+	sumsFilePattern = regexp.MustCompile(`(?i)(checksums?|sha256sums?)(\.txt)?$`)
+	// assetSuffixes are extensions of files with digest of a single asset.
+	assetSuffixes = []string{".sha256", ".sha256sum", ".sha256.txt"}
+)
+
+// FindAssets returns release assets that may contain digest of the asset with the specified name.
+// Files with digest of a single asset (e. g. "tool.tar.gz.sha256") go first.
+func FindAssets(assets []*github.ReleaseAsset, name string) []*github.ReleaseAsset {
+	var single, multiple []*github.ReleaseAsset
+
+	for _, a := range assets {
+		candidate := a.GetName()
+
+		for _, suffix := range assetSuffixes {
+			if strings.EqualFold(candidate, name+suffix) {
+				single = append(single, a)
+			}
+		}
+
+		if sumsFilePattern.MatchString(candidate) {
+			multiple = append(multiple, a)
+		}
+	}
+
+	return append(single, multiple...)
+}
+
+// IsChecksumFile reports whether file with the specified name contains digests.
+func IsChecksumFile(name string) bool {
+	lower := strings.ToLower(name)
+
+	for _, suffix := range assetSuffixes {
+		if strings.HasSuffix(lower, suffix) {
+			return true
+		}
+	}
+
+	return sumsFilePattern.MatchString(name)
+}
+
+// Parse finds digest of the file with the specified name in the checksum file contents.
+// It supports GNU coreutils format ("<digest>  name" or "<digest> *name"),
+// BSD format ("SHA256 (name) = <digest>") and files containing only the digest.
+func Parse(content []byte, name string) (string, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+
+	var lines []string
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			lines = append(lines, line)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("error reading checksum file: %w", err)
+	}
+
+	for _, line := range lines {
+		if m := bsdPattern.FindStringSubmatch(line); m != nil {
+			if sameFile(m[1], name) {
+				return strings.ToLower(m[2]), nil
+			}
+
+			continue
+		}
+
+		fields := strings.Fields(line)
+
+		switch {
+		case len(fields) == 1 && len(lines) == 1 && digestPattern.MatchString(fields[0]):
+			return strings.ToLower(fields[0]), nil
+		case len(fields) >= 2 && digestPattern.MatchString(fields[0]):
+			file := strings.TrimPrefix(strings.Join(fields[1:], " "), "*")
+			if sameFile(file, name) {
+				return strings.ToLower(fields[0]), nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("%w: no digest for '%s'", ErrNotFound, name)
+}
+
+// File calculates hex-encoded SHA-256 digest of the file.
+func File(src string) (string, error) {
+	file, err := os.Open(src)
+	if err != nil {
+		return "", fmt.Errorf("error opening file: %w", err)
+	}
+
+	defer func(file *os.File) {
+		_ = file.Close()
+	}(file)
+
+	h := sha256.New()
+	if _, err := io.Copy(h, file); err != nil {
+		return "", fmt.Errorf("error calculating file digest: %w", err)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Verify checks that digest matches the expected one.
+func Verify(digest string, expected string) error {
+	if !strings.EqualFold(digest, expected) {
+		return fmt.Errorf("%w: expected %s, got %s", ErrMismatch, expected, digest)
+	}
+
+	return nil
+}
+
+// sameFile compares file names ignoring folders (e. g. "./dist/tool.tar.gz" and "tool.tar.gz").
+func sameFile(a string, b string) bool {
+	return path.Base(strings.TrimSpace(a)) == path.Base(strings.TrimSpace(b))
+}
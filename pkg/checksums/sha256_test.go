@@ -0,0 +1,126 @@
+package checksums
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	digest := strings.Repeat("ab", 32)
+	other := strings.Repeat("cd", 32)
+
+	tests := []struct {
+		name    string
+		content string
+		file    string
+		want    string
+		wantErr error
+	}{
+		{
+			name:    "GNU format",
+			content: other + "  tool_linux_arm64.tar.gz\n" + digest + "  tool_linux_amd64.tar.gz\n",
+			file:    "tool_linux_amd64.tar.gz",
+			want:    digest,
+		},
+		{
+			name:    "GNU binary mode",
+			content: digest + " *tool.tar.gz\n",
+			file:    "tool.tar.gz",
+			want:    digest,
+		},
+		{
+			name:    "BSD format",
+			content: "SHA256 (tool.tar.gz) = " + digest + "\n",
+			file:    "tool.tar.gz",
+			want:    digest,
+		},
+		{
+			name:    "file names with folders",
+			content: digest + "  ./dist/tool.tar.gz\n",
+			file:    "tool.tar.gz",
+			want:    digest,
+		},
+		{
+			name:    "uppercase digest",
+			content: strings.ToUpper(digest) + "  tool.tar.gz\n",
+			file:    "tool.tar.gz",
+			want:    digest,
+		},
+		{
+			name:    "digest only",
+			content: digest + "\n",
+			file:    "tool.tar.gz",
+			want:    digest,
+		},
+		{
+			name:    "comments and empty lines",
+			content: "# checksums\n\n" + digest + "  tool.tar.gz\n",
+			file:    "tool.tar.gz",
+			want:    digest,
+		},
+		{
+			name:    "missing file",
+			content: digest + "  other.tar.gz\n",
+			file:    "tool.tar.gz",
+			wantErr: ErrNotFound,
+		},
+		{
+			name:    "several digests without names",
+			content: digest + "\n" + other + "\n",
+			file:    "tool.tar.gz",
+			wantErr: ErrNotFound,
+		},
+		{
+			name:    "short digest",
+			content: "abcdef  tool.tar.gz\n",
+			file:    "tool.tar.gz",
+			wantErr: ErrNotFound,
+		},
+		{
+			name:    "non-hex digest",
+			content: strings.Repeat("zz", 32) + "  tool.tar.gz\n",
+			file:    "tool.tar.gz",
+			wantErr: ErrNotFound,
+		},
+		{
+			name:    "empty file",
+			content: "",
+			file:    "tool.tar.gz",
+			wantErr: ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Parse([]byte(tt.content), tt.file)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("Parse() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+
+	digest := strings.Repeat("ab", 32)
+
+	if err := Verify(digest, strings.ToUpper(digest)); err != nil {
+		t.Errorf("Verify() error = %v", err)
+	}
+
+	if err := Verify(digest, strings.Repeat("cd", 32)); !errors.Is(err, ErrMismatch) {
+		t.Errorf("Verify() error = %v, want %v", err, ErrMismatch)
+	}
+}
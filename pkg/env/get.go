@@ -2,7 +2,9 @@ package env
 
 import (
 	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
 )
 
 func Get(name string, fallback string) string {
@@ -14,6 +16,22 @@ func Get(name string, fallback string) string {
 	return value
 }
 
+// GetPath returns path with "~" replaced with the user's home folder.
+func GetPath(name string, fallback string) string {
+	value := Get(name, fallback)
+
+	if value != "~" && !strings.HasPrefix(value, "~/") {
+		return value
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return value
+	}
+
+	return filepath.Join(home, strings.TrimPrefix(value, "~"))
+}
+
 func GetBool(name string, fallback bool) bool {
 	value := os.Getenv(name)
 	if value == "" {
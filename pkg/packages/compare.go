@@ -0,0 +1,143 @@
+package packages
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Compare returns -1 if a is older than b, 1 if a is newer than b and 0 if versions are equal.
+// Versions that can't be parsed are considered older than parsed ones and are compared as strings.
+// Prerelease versions are older than release versions with the same numbers (e. g. "1.0.0-rc.1" < "1.0.0").
+// Missing parts are considered to be zero (e. g. "1.2" == "1.2.0").
+func Compare(a, b Version) int {
+	switch {
+	case a.Components == nil && b.Components == nil:
+		return strings.Compare(a.Value, b.Value)
+	case a.Components == nil:
+		return -1
+	case b.Components == nil:
+		return 1
+	}
+
+	ca, cb := a.Components, b.Components
+
+	if c := compareInts(ca.Major, cb.Major); c != 0 {
+		return c
+	}
+
+	for _, pair := range [][2]*int{{ca.Minor, cb.Minor}, {ca.Patch, cb.Patch}, {ca.Revision, cb.Revision}} {
+		if c := compareInts(valueOrZero(pair[0]), valueOrZero(pair[1])); c != 0 {
+			return c
+		}
+	}
+
+	return compareSuffixes(ca.Suffix, cb.Suffix)
+}
+
+// Less reports whether a is older than b.
+func Less(a, b Version) bool {
+	return Compare(a, b) < 0
+}
+
+// Sort sorts versions from the oldest to the newest.
+func Sort(versions []Version) {
+	sort.SliceStable(versions, func(i, j int) bool {
+		return Less(versions[i], versions[j])
+	})
+}
+
+// compareSuffixes compares prerelease suffixes using semver rules:
+// identifiers are compared one by one, numeric identifiers are compared numerically
+// and have lower precedence than alphanumeric ones, and a shorter suffix has lower precedence.
+func compareSuffixes(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+
+	as, bs := splitIdentifiers(a), splitIdentifiers(b)
+
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		if c := compareIdentifiers(as[i], bs[i]); c != 0 {
+			return c
+		}
+	}
+
+	return compareInts(len(as), len(bs))
+}
+
+// compareIdentifiers compares identifiers in natural order, so "rc2" < "rc10".
+func compareIdentifiers(a, b string) int {
+	ac, bc := splitChunks(a), splitChunks(b)
+
+	for i := 0; i < len(ac) && i < len(bc); i++ {
+		an, aErr := strconv.Atoi(ac[i])
+		bn, bErr := strconv.Atoi(bc[i])
+
+		var c int
+
+		switch {
+		case aErr == nil && bErr == nil:
+			c = compareInts(an, bn)
+		case aErr == nil:
+			c = -1
+		case bErr == nil:
+			c = 1
+		default:
+			c = strings.Compare(ac[i], bc[i])
+		}
+
+		if c != 0 {
+			return c
+		}
+	}
+
+	return compareInts(len(ac), len(bc))
+}
+
+func splitIdentifiers(suffix string) []string {
+	return strings.FieldsFunc(strings.ToLower(suffix), func(r rune) bool {
+		return r == '.' || r == '-' || r == '_'
+	})
+}
+
+// splitChunks splits identifier into groups of digits and non-digits (e. g. "rc10" -> "rc", "10").
+func splitChunks(identifier string) []string {
+	var chunks []string
+
+	start := 0
+
+	for i := 1; i <= len(identifier); i++ {
+		if i == len(identifier) || unicode.IsDigit(rune(identifier[i])) != unicode.IsDigit(rune(identifier[i-1])) {
+			chunks = append(chunks, identifier[start:i])
+			start = i
+		}
+	}
+
+	return chunks
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func valueOrZero(p *int) int {
+	if p == nil {
+		return 0
+	}
+
+	return *p
+}
@@ -0,0 +1,81 @@
+package packages
+
+import "testing"
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		a    string
+		b    string
+		want int
+	}{
+		{a: "1.2.3", b: "1.2.3", want: 0},
+		{a: "v1.2.3", b: "1.2.3", want: 0},
+		{a: "1.2", b: "1.2.0", want: 0},
+		{a: "1.2.3", b: "1.2.4", want: -1},
+		{a: "1.10.0", b: "1.9.0", want: 1},
+		{a: "2.0.0", b: "1.99.99", want: 1},
+		{a: "1.2.3.4", b: "1.2.3", want: 1},
+		{a: "1.0.0-rc.1", b: "1.0.0", want: -1},
+		{a: "1.0.0-alpha", b: "1.0.0-beta", want: -1},
+		{a: "1.0.0-rc.2", b: "1.0.0-rc.10", want: -1},
+		{a: "1.0.0-rc.1", b: "1.0.0-rc", want: 1},
+		{a: "1.0.0-1", b: "1.0.0-alpha", want: -1},
+		{a: "1.0.0+build.1", b: "1.0.0+build.2", want: 0},
+		{a: "v1.2.3_linux", b: "1.2.3", want: 0},
+		{a: "1.0.0rc1", b: "1.0.0", want: -1},
+		{a: "2021-08-01", b: "2021.8.1", want: 0},
+		{a: "nightly", b: "0.0.1", want: -1},
+		{a: "nightly", b: "latest", want: 1},
+		{a: "nightly", b: "nightly", want: 0},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.a+" vs "+tt.b, func(t *testing.T) {
+			t.Parallel()
+
+			a, b := parseOrRaw(tt.a), parseOrRaw(tt.b)
+
+			if got := Compare(a, b); got != tt.want {
+				t.Errorf("Compare(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+
+			if got := Compare(b, a); got != -tt.want {
+				t.Errorf("Compare(%q, %q) = %d, want %d", tt.b, tt.a, got, -tt.want)
+			}
+		})
+	}
+}
+
+func TestSort(t *testing.T) {
+	t.Parallel()
+
+	values := []string{"1.10.0", "nightly", "1.2.0", "1.2.0-rc.1", "0.9", "1.2.0-beta"}
+	want := []string{"nightly", "0.9", "1.2.0-beta", "1.2.0-rc.1", "1.2.0", "1.10.0"}
+
+	versions := make([]Version, 0, len(values))
+	for _, v := range values {
+		versions = append(versions, parseOrRaw(v))
+	}
+
+	Sort(versions)
+
+	for i, v := range versions {
+		if v.Value != want[i] {
+			t.Fatalf("Sort() = %+v, want %v", versions, want)
+		}
+	}
+}
+
+// parseOrRaw parses version or returns it as is if it can't be parsed.
+func parseOrRaw(s string) Version {
+	v, err := ParseVersion(s)
+	if err != nil {
+		return Version{Value: s} //nolint:exhaustivestruct
+	}
+
+	return v
+}
@@ -0,0 +1,266 @@
+package packages
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+const (
+	opEqual          = "="
+	opNotEqual       = "!="
+	opGreater        = ">"
+	opGreaterOrEqual = ">="
+	opLess           = "<"
+	opLessOrEqual    = "<="
+	opCaret          = "^"
+	opTilde          = "~"
+)
+
+//nolint:gochecknoglobals
+var (
+	// operators are ordered so that longer operators are matched first.
+	operators = []string{
+		opGreaterOrEqual, opLessOrEqual, opNotEqual, opGreater, opLess, opEqual, opCaret, opTilde,
+	}
+	// constraintVersionPattern matches versions allowed in constraints. Unlike tags, they can't have arbitrary
+	// prefixes or suffixes, so typos (e. g. "^1.2foo") aren't silently accepted.
+	constraintVersionPattern = regexp.MustCompile(`^[vV]?\d+(?:\.\d+){0,3}(?:-[0-9A-Za-z.-]+)?(?:\+[0-9A-Za-z.-]+)?$`)
+)
+
+// Constraint is a set of version ranges (e. g. "^1.2", "~1.4", ">=2,<3" or "1.x || 2.x").
+// Ranges are separated with "||", conditions in range are separated with ",".
+// Zero value matches any version.
+type Constraint struct {
+	Value  string
+	ranges [][]condition
+}
+
+type condition struct {
+	op      string
+	version Version
+}
+
+// IsConstraint reports whether s should be parsed as a constraint rather than as an exact version.
+func IsConstraint(s string) bool {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return false
+	}
+
+	return strings.ContainsAny(s[:1], "=!<>^~*") ||
+		strings.Contains(s, ",") || strings.Contains(s, "||") ||
+		hasWildcard(s)
+}
+
+func ParseConstraint(s string) (Constraint, error) {
+	c := Constraint{Value: s} //nolint:exhaustivestruct
+
+	for _, rangeStr := range strings.Split(s, "||") {
+		var conditions []condition
+
+		for _, condStr := range strings.Split(rangeStr, ",") {
+			parsed, err := parseCondition(strings.TrimSpace(condStr))
+			if err != nil {
+				return Constraint{}, fmt.Errorf("error parsing constraint '%s': %w", s, err)
+			}
+
+			conditions = append(conditions, parsed...)
+		}
+
+		c.ranges = append(c.ranges, conditions)
+	}
+
+	return c, nil
+}
+
+// Matches reports whether version satisfies constraint.
+// Prerelease versions match only if constraint mentions a prerelease of the same major, minor and patch version
+// (e. g. ">=1.2.3-rc.1" matches "1.2.3-rc.2", but not "1.3.0-rc.1").
+func (c Constraint) Matches(v Version) bool {
+	if len(c.ranges) == 0 {
+		return true
+	}
+
+	if v.Components == nil {
+		return false
+	}
+
+	for _, conditions := range c.ranges {
+		if matchesAll(conditions, v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchesAll(conditions []condition, v Version) bool {
+	allowPrerelease := false
+
+	for _, cond := range conditions {
+		if !cond.matches(v) {
+			return false
+		}
+
+		allowPrerelease = allowPrerelease || cond.allowsPrereleaseOf(v)
+	}
+
+	return !v.IsPrerelease() || allowPrerelease
+}
+
+func (c condition) matches(v Version) bool {
+	cmp := Compare(v, c.version)
+
+	switch c.op {
+	case opEqual:
+		return cmp == 0
+	case opNotEqual:
+		return cmp != 0
+	case opGreater:
+		return cmp > 0
+	case opGreaterOrEqual:
+		return cmp >= 0
+	case opLess:
+		return cmp < 0
+	case opLessOrEqual:
+		return cmp <= 0
+	}
+
+	return false
+}
+
+func (c condition) allowsPrereleaseOf(v Version) bool {
+	if !c.version.IsPrerelease() {
+		return false
+	}
+
+	a, b := c.version.Components, v.Components
+
+	return a.Major == b.Major &&
+		valueOrZero(a.Minor) == valueOrZero(b.Minor) &&
+		valueOrZero(a.Patch) == valueOrZero(b.Patch) &&
+		valueOrZero(a.Revision) == valueOrZero(b.Revision)
+}
+
+// parseCondition parses a single condition.
+// Caret, tilde and wildcard conditions are expanded into pairs of lower and upper bounds.
+func parseCondition(s string) ([]condition, error) {
+	if s == "" {
+		return nil, fmt.Errorf("empty condition")
+	}
+
+	if s == "*" || s == "x" || s == "X" {
+		return nil, nil
+	}
+
+	op := opEqual
+
+	for _, candidate := range operators {
+		if strings.HasPrefix(s, candidate) {
+			op = candidate
+			s = strings.TrimSpace(strings.TrimPrefix(s, candidate))
+
+			break
+		}
+	}
+
+	if hasWildcard(s) {
+		if op != opEqual {
+			return nil, fmt.Errorf("wildcard can't be used with operator '%s'", op)
+		}
+
+		s, op = trimWildcard(s), opTilde
+	}
+
+	v, err := parseConstraintVersion(s)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op {
+	case opCaret:
+		return []condition{{opGreaterOrEqual, v}, {opLess, caretUpperBound(v.Components)}}, nil
+	case opTilde:
+		return []condition{{opGreaterOrEqual, v}, {opLess, tildeUpperBound(v.Components)}}, nil
+	default:
+		return []condition{{op, v}}, nil
+	}
+}
+
+// parseConstraintVersion parses version of the condition. Only semver-like versions with optional "v" prefix
+// are allowed (e. g. "1.2", "v1.2.3-rc.1").
+func parseConstraintVersion(s string) (Version, error) {
+	if s == "" {
+		return Version{}, fmt.Errorf("empty version")
+	}
+
+	if strings.IndexFunc(s, unicode.IsSpace) != -1 {
+		return Version{}, fmt.Errorf("invalid version '%s': conditions must be separated with ','", s)
+	}
+
+	if !constraintVersionPattern.MatchString(s) {
+		return Version{}, fmt.Errorf("invalid version '%s'", s)
+	}
+
+	return ParseVersion(s)
+}
+
+// caretUpperBound allows changes that don't modify the leftmost non-zero part (e. g. ^1.2 -> <2.0.0, ^0.2.3 -> <0.3.0).
+func caretUpperBound(c *Components) Version {
+	switch {
+	case c.Major != 0 || c.Minor == nil:
+		return bound(c.Major + 1)
+	case *c.Minor != 0 || c.Patch == nil:
+		return bound(c.Major, *c.Minor+1)
+	default:
+		return bound(c.Major, *c.Minor, *c.Patch+1)
+	}
+}
+
+// tildeUpperBound allows patch changes if minor version is specified and minor changes otherwise
+// (e. g. ~1.4 -> <1.5.0, ~1.4.2 -> <1.5.0, ~1 -> <2.0.0).
+func tildeUpperBound(c *Components) Version {
+	if c.Minor == nil {
+		return bound(c.Major + 1)
+	}
+
+	return bound(c.Major, *c.Minor+1)
+}
+
+// bound creates version with given parts.
+func bound(parts ...int) Version {
+	values := make([]string, 0, len(parts))
+	for _, p := range parts {
+		values = append(values, fmt.Sprint(p))
+	}
+
+	// Bound is always valid, so error can be ignored.
+	v, _ := ParseVersion(strings.Join(values, "."))
+
+	return v
+}
+
+func hasWildcard(s string) bool {
+	for _, part := range strings.Split(s, ".") {
+		if part == "*" || part == "x" || part == "X" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// trimWildcard removes wildcard parts (e. g. "1.2.x" -> "1.2").
+func trimWildcard(s string) string {
+	parts := strings.Split(s, ".")
+
+	for i, part := range parts {
+		if part == "*" || part == "x" || part == "X" {
+			return strings.Join(parts[:i], ".")
+		}
+	}
+
+	return s
+}
@@ -0,0 +1,157 @@
+package packages
+
+import "testing"
+
+func TestParseConstraint(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		constraint string
+		wantErr    bool
+	}{
+		{constraint: "^1.2"},
+		{constraint: "~1.4.2"},
+		{constraint: ">=2,<3"},
+		{constraint: ">= 2, < 3"},
+		{constraint: "1.x || 2.x"},
+		{constraint: "*"},
+		{constraint: ">=v1.2.3-rc.1"},
+		{constraint: "=1.2.3+build.1"},
+		{constraint: ">=2 <3", wantErr: true},
+		{constraint: ">=3.8 <3.10", wantErr: true},
+		{constraint: "^1.2foo", wantErr: true},
+		{constraint: "^1.2 foo", wantErr: true},
+		{constraint: ">=<2", wantErr: true},
+		{constraint: "^^1", wantErr: true},
+		{constraint: "~release-1.2", wantErr: true},
+		{constraint: ">=1.2.3.4.5", wantErr: true},
+		{constraint: ">=", wantErr: true},
+		{constraint: ">=1,", wantErr: true},
+		{constraint: "||1", wantErr: true},
+		{constraint: ">x", wantErr: true},
+		{constraint: "x.1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.constraint, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := ParseConstraint(tt.constraint)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseConstraint(%q) error = %v, wantErr %v", tt.constraint, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConstraintMatches(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		constraint string
+		version    string
+		want       bool
+	}{
+		{constraint: "^1.2", version: "1.2.0", want: true},
+		{constraint: "^1.2", version: "v1.9.9", want: true},
+		{constraint: "^1.2", version: "1.1.9", want: false},
+		{constraint: "^1.2", version: "2.0.0", want: false},
+		{constraint: "^0.2.3", version: "0.2.9", want: true},
+		{constraint: "^0.2.3", version: "0.3.0", want: false},
+		{constraint: "^0.0.3", version: "0.0.4", want: false},
+		{constraint: "~1.4", version: "1.4.7", want: true},
+		{constraint: "~1.4", version: "1.5.0", want: false},
+		{constraint: "~1", version: "1.9.0", want: true},
+		{constraint: ">=2,<3", version: "2.5.0", want: true},
+		{constraint: ">=2,<3", version: "3.5.0", want: false},
+		{constraint: ">=3.8,<3.10", version: "3.10.0", want: false},
+		{constraint: ">=3.8,<3.10", version: "3.9.1", want: true},
+		{constraint: "1.x || 3.x", version: "3.1.0", want: true},
+		{constraint: "1.x || 3.x", version: "2.1.0", want: false},
+		{constraint: "1.2.x", version: "1.2.7", want: true},
+		{constraint: "1.2.x", version: "1.3.0", want: false},
+		{constraint: "!=1.2.3", version: "1.2.3", want: false},
+		{constraint: "!=1.2.3", version: "1.2.4", want: true},
+		{constraint: "=1.2", version: "1.2.0", want: true},
+		{constraint: "1.2.3", version: "1.2.3", want: true},
+		{constraint: "<=1.2", version: "1.2.0", want: true},
+		{constraint: ">1.2", version: "1.2.0", want: false},
+		{constraint: "*", version: "5.0.0", want: true},
+		{constraint: "^1.2", version: "1.3.0-rc.1", want: false},
+		{constraint: ">=1.3.0-rc.1", version: "1.3.0-rc.2", want: true},
+		{constraint: ">=1.3.0-rc.1", version: "1.4.0-rc.1", want: false},
+		{constraint: ">=1.3.0-rc.1", version: "1.3.0", want: true},
+		{constraint: "^1.2", version: "nightly", want: false},
+		{constraint: "", version: "nightly", want: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.constraint+" "+tt.version, func(t *testing.T) {
+			t.Parallel()
+
+			var c Constraint
+
+			if tt.constraint != "" {
+				var err error
+
+				c, err = ParseConstraint(tt.constraint)
+				if err != nil {
+					t.Fatalf("ParseConstraint(%q) error = %v", tt.constraint, err)
+				}
+			}
+
+			// Tags that can't be parsed are matched as raw versions.
+			v, err := ParseVersion(tt.version)
+			if err != nil {
+				v = Version{Value: tt.version} //nolint:exhaustivestruct
+			}
+
+			if got := c.Matches(v); got != tt.want {
+				t.Errorf("Constraint(%q).Matches(%q) = %v, want %v", tt.constraint, tt.version, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePackageConstraint(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		wantConstraint string
+		wantVersion    string
+		wantErr        bool
+	}{
+		{name: "helm/helm@^3.8", wantConstraint: "^3.8"},
+		{name: "helm/helm@>=3.8,<3.10", wantConstraint: ">=3.8,<3.10"},
+		{name: "helm/helm@v3.8.0", wantVersion: "v3.8.0"},
+		{name: "helm/helm@>=3.8 <3.10", wantErr: true},
+		{name: "helm/helm@^3.8foo", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			pkg, err := ParsePackage(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePackage(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if pkg.Constraint != tt.wantConstraint || pkg.Version.Value != tt.wantVersion {
+				t.Errorf("ParsePackage(%q) = constraint %q, version %q, want %q, %q",
+					tt.name, pkg.Constraint, pkg.Version.Value, tt.wantConstraint, tt.wantVersion)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package packages
+
+import "time"
+
+// FindVersion returns installed version with the same value or the same version numbers
+// (e. g. "1.2.3" matches "v1.2.3").
+func (m Metadata) FindVersion(value string) (InstalledVersion, bool) {
+	for _, v := range m.Installation.Versions {
+		if v.Version.Value == value {
+			return v, true
+		}
+	}
+
+	version, err := ParseVersion(value)
+	if err != nil {
+		return InstalledVersion{}, false
+	}
+
+	for _, v := range m.Installation.Versions {
+		if v.Version.Components != nil && Compare(v.Version, version) == 0 {
+			return v, true
+		}
+	}
+
+	return InstalledVersion{}, false
+}
+
+// IsActive reports whether installed version is the one symlinks point to.
+func (m Metadata) IsActive(v InstalledVersion) bool {
+	return m.Installation.Package == v.Package
+}
+
+// AddVersion adds installed version to the list of versions or replaces version installed to the same folder.
+func (m *Metadata) AddVersion(v InstalledVersion) {
+	versions := make([]InstalledVersion, 0, len(m.Installation.Versions)+1)
+
+	for _, installed := range m.Installation.Versions {
+		if installed.Package != v.Package {
+			versions = append(versions, installed)
+		}
+	}
+
+	m.Installation.Versions = append(versions, v)
+}
+
+// RemoveVersion removes version installed to the folder from the list of versions and from history.
+func (m *Metadata) RemoveVersion(v InstalledVersion) {
+	versions := make([]InstalledVersion, 0, len(m.Installation.Versions))
+
+	for _, installed := range m.Installation.Versions {
+		if installed.Package != v.Package {
+			versions = append(versions, installed)
+		}
+	}
+
+	history := make([]HistoryEntry, 0, len(m.Installation.History))
+
+	for _, entry := range m.Installation.History {
+		if entry.Package != v.Package {
+			history = append(history, entry)
+		}
+	}
+
+	m.Installation.Versions = versions
+	m.Installation.History = history
+}
+
+// Previous returns the most recent version from history that is still installed and its index in history.
+func (m Metadata) Previous() (InstalledVersion, int, bool) {
+	for i := len(m.Installation.History) - 1; i >= 0; i-- {
+		entry := m.Installation.History[i]
+
+		for _, v := range m.Installation.Versions {
+			if v.Package == entry.Package && !m.IsActive(v) {
+				return v, i, true
+			}
+		}
+	}
+
+	return InstalledVersion{}, 0, false
+}
+
+// Activate makes version the active one and adds previously active version to history. It doesn't change symlinks.
+func (m *Metadata) Activate(v InstalledVersion) {
+	if m.Installation.Package != "" && m.Installation.Package != v.Package {
+		m.Installation.History = append(m.Installation.History, HistoryEntry{
+			Version:    m.Package.Version,
+			Package:    m.Installation.Package,
+			ReplacedAt: time.Now().UTC(),
+		})
+	}
+
+	m.Package.Version = v.Version
+	m.Asset = v.Asset
+	m.Installation.Package = v.Package
+}
@@ -2,8 +2,21 @@ package packages
 
 import (
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
+	"unicode"
+)
+
+const maxVersionParts = 4
+
+//nolint:gochecknoglobals
+var (
+	// datePattern matches date-based versions with dashes (e. g. "2021-08-01").
+	datePattern = regexp.MustCompile(`^(\d{4})-(\d{1,2})-(\d{1,2})(.*)$`)
+	// versionStartPattern matches the beginning of version after prefix (e. g. "v1" in "k9s-v1.2.3").
+	versionStartPattern = regexp.MustCompile(`(?:^|[-_/ ])[vV]?(\d)`)
+	partNames           = [maxVersionParts]string{"major", "minor", "patch", "revision"}
 )
 
 //nolint:gomnd
@@ -34,18 +47,35 @@ func ParsePackage(name string) (Package, error) {
 		repo = ss[0]
 	}
 
+	if IsConstraint(versionStr) {
+		if _, err := ParseConstraint(versionStr); err != nil {
+			return Package{}, err
+		}
+
+		return Package{ //nolint:exhaustivestruct
+			Owner:      username,
+			Repo:       repo,
+			Constraint: versionStr,
+		}, nil
+	}
+
 	version, err := ParseVersion(versionStr)
 	if err != nil {
-		return Package{}, fmt.Errorf("error parsing version: %w", err)
+		// Tags like "nightly" can't be parsed, but can still be used to find a release.
+		version = Version{Value: versionStr} //nolint:exhaustivestruct
 	}
 
-	return Package{
+	return Package{ //nolint:exhaustivestruct
 		Owner:   username,
 		Repo:    repo,
 		Version: version,
+		Pinned:  version.Value != "",
 	}, nil
 }
 
+// ParseVersion parses semver-like versions: "1.2.3", "v1.2.3-rc.1+build", "1.2.3.4",
+// date-based versions ("2021.08.01", "2021-08-01", "20210801") and versions with prefixes ("release-1.2").
+// Up to 4 numeric parts are supported.
 func ParseVersion(version string) (Version, error) {
 	rawVersion := Version{Value: version} //nolint:exhaustivestruct
 
@@ -53,48 +83,107 @@ func ParseVersion(version string) (Version, error) {
 		return rawVersion, nil
 	}
 
-	trimmed := strings.TrimPrefix(version, "v")
-	parts := strings.Split(trimmed, "-")
+	trimmed, err := trimVersionPrefix(version)
+	if err != nil {
+		return rawVersion, err
+	}
+
+	trimmed, build := splitOnce(trimmed, "+")
 
-	mainPart, suffix := "", ""
-	if len(parts) > 1 {
-		suffix = strings.Join(parts[1:], "-")
+	if m := datePattern.FindStringSubmatch(trimmed); m != nil {
+		trimmed = fmt.Sprintf("%s.%s.%s%s", m[1], m[2], m[3], m[4])
 	}
 
-	mainParts := strings.Split(mainPart, ".")
+	mainPart, suffix, suffixBuild := splitSuffix(trimmed)
 
-	major, err := strconv.Atoi(mainParts[0])
-	if err != nil {
-		return rawVersion, fmt.Errorf("error parsing major version: %w", err)
+	if suffixBuild != "" && build != "" {
+		build = suffixBuild + "." + build
+	} else if suffixBuild != "" {
+		build = suffixBuild
+	}
+
+	mainParts := strings.Split(mainPart, ".")
+	if len(mainParts) > maxVersionParts {
+		return rawVersion, fmt.Errorf("version can't contain more than %d numeric parts", maxVersionParts)
 	}
 
-	var minor, patch *int
+	numbers := make([]int, len(mainParts))
 
-	if len(mainParts) >= 2 { //nolint:gomnd
-		i, err := strconv.Atoi(mainParts[1])
+	for i, part := range mainParts {
+		n, err := strconv.Atoi(part)
 		if err != nil {
-			return rawVersion, fmt.Errorf("error parsing minor version: %w", err)
+			return rawVersion, fmt.Errorf("error parsing %s version: %w", partNames[i], err)
 		}
 
-		minor = &i
+		numbers[i] = n
 	}
 
-	if len(mainParts) >= 2 { //nolint:gomnd
-		i, err := strconv.Atoi(mainParts[1])
-		if err != nil {
-			return rawVersion, fmt.Errorf("error parsing patch version: %w", err)
-		}
+	components := &Components{ //nolint:exhaustivestruct
+		Major:  numbers[0],
+		Suffix: suffix,
+		Build:  build,
+	}
 
-		patch = &i
+	for i, p := range []**int{&components.Minor, &components.Patch, &components.Revision} {
+		if i+1 < len(numbers) {
+			*p = &numbers[i+1]
+		}
 	}
 
 	return Version{
-		Value: version,
-		Components: &Components{
-			Major:  major,
-			Minor:  minor,
-			Patch:  patch,
-			Suffix: suffix,
-		},
+		Value:      version,
+		Components: components,
 	}, nil
 }
+
+// trimVersionPrefix removes prefix before version numbers (e. g. "v", "release-" or "k9s-v").
+// If there is no separator before numbers, everything before the first digit is removed (e. g. "go" in "go1.17").
+func trimVersionPrefix(version string) (string, error) {
+	if loc := versionStartPattern.FindStringSubmatchIndex(version); loc != nil {
+		return version[loc[2]:], nil
+	}
+
+	i := strings.IndexFunc(version, unicode.IsDigit)
+	if i == -1 {
+		return "", fmt.Errorf("version '%s' doesn't contain numbers", version)
+	}
+
+	return version[i:], nil
+}
+
+// splitSuffix splits version into numeric part, prerelease suffix and build metadata.
+// Prerelease suffix is separated with a dash ("1.2.3-rc.1") or follows numbers directly ("1.2.3rc1").
+// Suffixes separated with other characters are build metadata (e. g. "linux" in "1.2.3_linux").
+func splitSuffix(version string) (string, string, string) {
+	if mainPart, suffix := splitOnce(version, "-"); suffix != "" {
+		return mainPart, suffix, ""
+	}
+
+	i := strings.IndexFunc(version, func(r rune) bool {
+		return !unicode.IsDigit(r) && r != '.'
+	})
+	if i == -1 {
+		return version, "", ""
+	}
+
+	mainPart, suffix := version[:i], version[i:]
+
+	if i > 0 && unicode.IsDigit(rune(version[i-1])) && unicode.IsLetter(rune(suffix[0])) {
+		return mainPart, suffix, ""
+	}
+
+	build := strings.TrimLeftFunc(suffix, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	return strings.TrimRight(mainPart, "."), "", build
+}
+
+func splitOnce(s string, sep string) (string, string) {
+	parts := strings.SplitN(s, sep, 2) //nolint:gomnd
+	if len(parts) == 1 {
+		return parts[0], ""
+	}
+
+	return parts[0], parts[1]
+}
@@ -0,0 +1,145 @@
+package packages
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		version string
+		// want is a version formatted by formatComponents.
+		want    string
+		wantErr bool
+	}{
+		{version: "1.2.3", want: "1.2.3"},
+		{version: "v1.2.3", want: "1.2.3"},
+		{version: "V1.2", want: "1.2"},
+		{version: "1", want: "1"},
+		{version: "1.2.3.4", want: "1.2.3.4"},
+		{version: "v1.2.3-rc.1+build.5", want: "1.2.3-rc.1+build.5"},
+		{version: "1.2.3+build", want: "1.2.3+build"},
+		{version: "1.2.3rc1", want: "1.2.3-rc1"},
+		{version: "1.2.3.beta", want: "1.2.3+beta"},
+		{version: "v1.2.3_linux", want: "1.2.3+linux"},
+		{version: "v1.2.3_linux+5", want: "1.2.3+linux.5"},
+		{version: "v1.2.3-rc.1_linux", want: "1.2.3-rc.1_linux"},
+		{version: "2021.08.01", want: "2021.8.1"},
+		{version: "2021-08-01", want: "2021.8.1"},
+		{version: "20210801", want: "20210801"},
+		{version: "release-1.2", want: "1.2"},
+		{version: "k9s-v0.25.0", want: "0.25.0"},
+		{version: "kustomize/v4.4.0", want: "4.4.0"},
+		{version: "go1.17", want: "1.17"},
+		{version: "", want: ""},
+		{version: "nightly", wantErr: true},
+		{version: "latest", wantErr: true},
+		{version: "1.2.3.4.5", wantErr: true},
+		{version: "1..2", wantErr: true},
+		{version: "1.x", want: "1+x"},
+		{version: "v", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.version, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseVersion(tt.version)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseVersion(%q) error = %v, wantErr %v", tt.version, err, tt.wantErr)
+			}
+
+			if got.Value != tt.version {
+				t.Errorf("ParseVersion(%q).Value = %q, want %q", tt.version, got.Value, tt.version)
+			}
+
+			if tt.wantErr {
+				if got.Components != nil {
+					t.Errorf("ParseVersion(%q) returned components for malformed version", tt.version)
+				}
+
+				return
+			}
+
+			if s := formatComponents(got.Components); s != tt.want {
+				t.Errorf("ParseVersion(%q) = %s, want %s", tt.version, s, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePackage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		wantOwner  string
+		wantRepo   string
+		wantValue  string
+		wantPinned bool
+		wantErr    bool
+	}{
+		{name: "derailed/k9s", wantOwner: "derailed", wantRepo: "k9s"},
+		{name: "k9s", wantOwner: "k9s", wantRepo: "k9s"},
+		{name: "derailed/k9s@v0.25.0", wantOwner: "derailed", wantRepo: "k9s", wantValue: "v0.25.0", wantPinned: true},
+		{name: "neovim/neovim@nightly", wantOwner: "neovim", wantRepo: "neovim", wantValue: "nightly", wantPinned: true},
+		{name: "a/b/c", wantErr: true},
+		{name: "a@1@2", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParsePackage(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePackage(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if got.Owner != tt.wantOwner || got.Repo != tt.wantRepo || got.Version.Value != tt.wantValue ||
+				got.Pinned != tt.wantPinned {
+				t.Errorf("ParsePackage(%q) = %+v", tt.name, got)
+			}
+		})
+	}
+}
+
+// formatComponents formats version components as "major.minor.patch.revision-suffix+build" omitting missing parts.
+func formatComponents(c *Components) string {
+	if c == nil {
+		return ""
+	}
+
+	parts := []string{fmt.Sprint(c.Major)}
+
+	for _, p := range []*int{c.Minor, c.Patch, c.Revision} {
+		if p == nil {
+			break
+		}
+
+		parts = append(parts, fmt.Sprint(*p))
+	}
+
+	s := strings.Join(parts, ".")
+
+	if c.Suffix != "" {
+		s += "-" + c.Suffix
+	}
+
+	if c.Build != "" {
+		s += "+" + c.Build
+	}
+
+	return s
+}
@@ -1,10 +1,18 @@
 package packages
 
+import "time"
+
+// Components are parsed parts of version.
+// Minor, Patch and Revision are nil when version doesn't contain them (e. g. "v2" or "1.4").
 type Components struct {
-	Major  int    `json:"major"`
-	Minor  *int   `json:"minor"`
-	Patch  *int   `json:"patch"`
+	Major    int  `json:"major"`
+	Minor    *int `json:"minor"`
+	Patch    *int `json:"patch"`
+	Revision *int `json:"revision"`
+	// Suffix is a prerelease part of version (e. g. "rc.1" in "v1.2.3-rc.1").
 	Suffix string `json:"suffix"`
+	// Build is a build metadata (e. g. "linux" in "1.2.3+linux"). It's ignored when comparing versions.
+	Build string `json:"build"`
 }
 
 type Version struct {
@@ -12,18 +20,75 @@ type Version struct {
 	Components *Components `json:"components"`
 }
 
+// IsPrerelease reports whether version has a prerelease suffix.
+func (v Version) IsPrerelease() bool {
+	return v.Components != nil && v.Components.Suffix != ""
+}
+
 type Package struct {
 	Owner   string  `json:"owner"`
 	Repo    string  `json:"repo"`
 	Version Version `json:"version"`
+	// Pinned is set when the package was installed with an explicit version
+	// and shouldn't be moved to other releases automatically.
+	Pinned bool `json:"pinned"`
+	// Constraint limits versions the package can be installed or upgraded to (e. g. "^1.2").
+	Constraint string `json:"constraint"`
+	// Prerelease is set when the package follows prerelease channel, so prereleases are installed on upgrades.
+	Prerelease bool `json:"prerelease"`
+	// AssetPattern selects asset on upgrades instead of platform detection (e. g. "tool_.+_linux_amd64\.tar\.gz").
+	AssetPattern string `json:"assetPattern"`
 }
 
 type Installation struct {
+	// Package is a folder of the active version.
 	Package  string   `json:"package"`
 	Symlinks []string `json:"symlink"`
+	// Files are created outside of package folder (e. g. desktop entries and icons).
+	Files []string `json:"files"`
+	// Desktop is set when the package was integrated with desktop environment.
+	Desktop bool `json:"desktop"`
+	// Versions are all versions of the package installed side by side, including the active one.
+	Versions []InstalledVersion `json:"versions"`
+	// History contains previously active versions from the oldest to the newest.
+	History []HistoryEntry `json:"history"`
+}
+
+// HistoryEntry is a version that was active before it was replaced with another version.
+type HistoryEntry struct {
+	Version Version `json:"version"`
+	// Package is a folder of the version.
+	Package string `json:"package"`
+	// ReplacedAt is a time when another version became active.
+	ReplacedAt time.Time `json:"replacedAt"`
+}
+
+// InstalledVersion is a version of the package stored in its own folder.
+type InstalledVersion struct {
+	Version Version `json:"version"`
+	Asset   Asset   `json:"asset"`
+	// Package is a folder the version was installed to.
+	Package string `json:"package"`
+	// Binaries is a folder symlinks are created for (e. g. "usr" folder of deb packages).
+	Binaries string `json:"binaries"`
+	// AppImage is set when the version was installed from AppImage.
+	AppImage bool `json:"appimage"`
+}
+
+// Asset is a release asset the package was installed from.
+type Asset struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+	// SHA256 is a hex-encoded digest of the downloaded file.
+	SHA256 string `json:"sha256"`
+	// Verified is set when digest was verified using checksum file published in the release.
+	Verified bool `json:"verified"`
+	// Signature is a type of verified signature (e. g. "minisign"). It's empty if signature wasn't verified.
+	Signature string `json:"signature"`
 }
 
 type Metadata struct {
 	Package      Package      `json:"package"`
+	Asset        Asset        `json:"asset"`
 	Installation Installation `json:"installation"`
 }
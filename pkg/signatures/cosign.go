@@ -0,0 +1,92 @@
+package signatures
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+)
+
+// cosignBundle contains fields of both legacy cosign bundles and sigstore bundles that are required
+// for verification with a public key.
+type cosignBundle struct {
+	Base64Signature  string `json:"base64Signature"`
+	MessageSignature struct {
+		Signature string `json:"signature"`
+	} `json:"messageSignature"`
+}
+
+func verifyCosign(signature []byte, data io.Reader, keys []Key) error {
+	sig, err := parseCosignSignature(signature)
+	if err != nil {
+		return err
+	}
+
+	h := sha256.New()
+	if _, err := io.Copy(h, data); err != nil {
+		return fmt.Errorf("error hashing signed data: %w", err)
+	}
+
+	digest := h.Sum(nil)
+
+	for _, k := range keys {
+		key, err := parseCosignKey(k.Value)
+		if err != nil {
+			return err
+		}
+
+		if ecdsa.VerifyASN1(key, digest, sig) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: cosign signature doesn't match any trusted key", ErrInvalidSignature)
+}
+
+// parseCosignSignature parses base64-encoded signature or signature from bundle.
+func parseCosignSignature(signature []byte) ([]byte, error) {
+	trimmed := bytes.TrimSpace(signature)
+
+	if bytes.HasPrefix(trimmed, []byte("{")) {
+		var bundle cosignBundle
+		if err := json.Unmarshal(trimmed, &bundle); err != nil {
+			return nil, fmt.Errorf("error parsing cosign bundle: %w", err)
+		}
+
+		trimmed = []byte(bundle.Base64Signature)
+		if len(trimmed) == 0 {
+			trimmed = []byte(bundle.MessageSignature.Signature)
+		}
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(string(trimmed))
+	if err != nil {
+		return nil, fmt.Errorf("error decoding cosign signature: %w", err)
+	}
+
+	return sig, nil
+}
+
+func parseCosignKey(s string) (*ecdsa.PublicKey, error) {
+	block, _ := pem.Decode([]byte(s))
+	if block == nil {
+		return nil, fmt.Errorf("invalid cosign public key")
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing cosign public key: %w", err)
+	}
+
+	ecdsaKey, ok := key.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("unsupported cosign public key type %T", key)
+	}
+
+	return ecdsaKey, nil
+}
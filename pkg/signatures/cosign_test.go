@@ -0,0 +1,135 @@
+package signatures
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func newCosignKey(t *testing.T) (*ecdsa.PrivateKey, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return key, string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: b})) //nolint:exhaustivestruct
+}
+
+func signCosign(t *testing.T, key *ecdsa.PrivateKey, data string) string {
+	t.Helper()
+
+	digest := sha256.Sum256([]byte(data))
+
+	sig, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return base64.StdEncoding.EncodeToString(sig)
+}
+
+func TestVerifyCosign(t *testing.T) {
+	t.Parallel()
+
+	const data = "release asset contents"
+
+	key, publicKey := newCosignKey(t)
+	_, otherKey := newCosignKey(t)
+
+	sig := signCosign(t, key, data)
+
+	tests := []struct {
+		name      string
+		signature string
+		data      string
+		keys      []string
+		wantErr   error
+	}{
+		{name: "valid", signature: sig + "\n", data: data, keys: []string{publicKey}},
+		{name: "one of trusted keys", signature: sig, data: data, keys: []string{otherKey, publicKey}},
+		{name: "legacy bundle", signature: `{"base64Signature":"` + sig + `"}`, data: data, keys: []string{publicKey}},
+		{
+			name:      "sigstore bundle",
+			signature: `{"messageSignature":{"signature":"` + sig + `"}}`,
+			data:      data,
+			keys:      []string{publicKey},
+		},
+		{name: "tampered payload", signature: sig, data: data + "!", keys: []string{publicKey},
+			wantErr: ErrInvalidSignature},
+		{name: "wrong key", signature: sig, data: data, keys: []string{otherKey}, wantErr: ErrInvalidSignature},
+		{
+			name:      "bundle with tampered payload",
+			signature: `{"base64Signature":"` + sig + `"}`,
+			data:      data + "!",
+			keys:      []string{publicKey},
+			wantErr:   ErrInvalidSignature,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			keys := make([]Key, 0, len(tt.keys))
+			for _, k := range tt.keys {
+				keys = append(keys, Key{Type: TypeCosign, Value: k})
+			}
+
+			_, err := Verify(TypeCosign, []byte(tt.signature), strings.NewReader(tt.data), keys)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Verify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerifyCosignMalformed(t *testing.T) {
+	t.Parallel()
+
+	_, publicKey := newCosignKey(t)
+	invalidBlock := &pem.Block{Type: "PUBLIC KEY", Bytes: []byte("key")} //nolint:exhaustivestruct
+	invalidKey := string(pem.EncodeToMemory(invalidBlock))
+
+	tests := []struct {
+		name      string
+		signature string
+		key       string
+	}{
+		{name: "malformed bundle", signature: `{"base64Signature":`, key: publicKey},
+		{name: "bundle without signature", signature: `{"mediaType":"bundle"}`, key: publicKey},
+		{name: "bundle with invalid base64", signature: `{"base64Signature":"!!!"}`, key: publicKey},
+		{name: "invalid base64", signature: "!!!", key: publicKey},
+		{name: "invalid key", signature: "c2ln", key: "not a key"},
+		{name: "invalid key contents", signature: "c2ln", key: invalidKey},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := Verify(TypeCosign, []byte(tt.signature), strings.NewReader("data"),
+				[]Key{{Type: TypeCosign, Value: tt.key}})
+			if err == nil {
+				t.Errorf("Verify() error = nil, want error")
+			}
+		})
+	}
+}
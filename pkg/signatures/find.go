@@ -0,0 +1,70 @@
+package signatures
+
+import (
+	"strings"
+
+	"github.com/google/go-github/v39/github"
+)
+
+// Candidate is a release asset that may contain signature.
+type Candidate struct {
+	Asset *github.ReleaseAsset
+	// Type is TypeUnknown when it depends on file contents (e. g. ".sig" is used by both cosign and GPG).
+	Type Type
+}
+
+type signatureSuffix struct {
+	ext string
+	t   Type
+}
+
+//nolint:gochecknoglobals
+var signatureSuffixes = []signatureSuffix{
+	{".minisig", TypeMinisign},
+	{".asc", TypeGPG},
+	{".bundle", TypeCosign},
+	{".cosign.bundle", TypeCosign},
+	{".sigstore", TypeCosign},
+	{".sigstore.json", TypeCosign},
+	{".sig", TypeUnknown},
+}
+
+// FindSignatures returns release assets with signatures of the file with the specified name.
+func FindSignatures(assets []*github.ReleaseAsset, name string) []Candidate {
+	var result []Candidate
+
+	for _, suffix := range signatureSuffixes {
+		for _, a := range assets {
+			if strings.EqualFold(a.GetName(), name+suffix.ext) {
+				result = append(result, Candidate{Asset: a, Type: suffix.t})
+			}
+		}
+	}
+
+	return result
+}
+
+// IsSignatureFile reports whether file with the specified name contains signature.
+func IsSignatureFile(name string) bool {
+	lower := strings.ToLower(name)
+
+	for _, suffix := range signatureSuffixes {
+		if strings.HasSuffix(lower, suffix.ext) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// detectSignatureType detects type of ".sig" signatures:
+// GPG writes binary OpenPGP packets, while cosign writes base64-encoded signatures.
+func detectSignatureType(signature []byte) Type {
+	const openPGPPacketTag = 0x80
+
+	if len(signature) > 0 && signature[0]&openPGPPacketTag != 0 {
+		return TypeGPG
+	}
+
+	return TypeCosign
+}
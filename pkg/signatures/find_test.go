@@ -0,0 +1,88 @@
+package signatures
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/v39/github"
+)
+
+func TestDetectSignatureType(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		signature []byte
+		want      Type
+	}{
+		{name: "binary gpg", signature: []byte{0x89, 0x01, 0x33}, want: TypeGPG},
+		{name: "new format gpg", signature: []byte{0xc2, 0xc0}, want: TypeGPG},
+		{name: "cosign", signature: []byte("MEUCIQD..."), want: TypeCosign},
+		{name: "empty", signature: nil, want: TypeCosign},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := detectSignatureType(tt.signature); got != tt.want {
+				t.Errorf("detectSignatureType() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindSignatures(t *testing.T) {
+	t.Parallel()
+
+	names := []string{
+		"tool.tar.gz",
+		"tool.tar.gz.sig",
+		"tool.tar.gz.minisig",
+		"tool.tar.gz.asc",
+		"tool.tar.gz.sigstore.json",
+		"other.tar.gz.minisig",
+	}
+
+	assets := make([]*github.ReleaseAsset, 0, len(names))
+	for _, name := range names {
+		assets = append(assets, &github.ReleaseAsset{Name: github.String(name)}) //nolint:exhaustivestruct
+	}
+
+	var got []string
+
+	types := map[string]Type{}
+
+	for _, c := range FindSignatures(assets, "tool.tar.gz") {
+		got = append(got, c.Asset.GetName())
+		types[c.Asset.GetName()] = c.Type
+	}
+
+	want := []string{"tool.tar.gz.minisig", "tool.tar.gz.asc", "tool.tar.gz.sigstore.json", "tool.tar.gz.sig"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("FindSignatures() = %v, want %v", got, want)
+	}
+
+	if types["tool.tar.gz.sig"] != TypeUnknown || types["tool.tar.gz.asc"] != TypeGPG {
+		t.Errorf("FindSignatures() types = %v", types)
+	}
+
+	if IsSignatureFile("tool.tar.gz") || !IsSignatureFile("TOOL.TAR.GZ.MINISIG") {
+		t.Errorf("IsSignatureFile() detected signatures incorrectly")
+	}
+}
+
+func TestVerifyDetectsType(t *testing.T) {
+	t.Parallel()
+
+	signer := newMinisignSigner(1, "12345678")
+
+	// Signatures with unknown type are detected using contents, so keys of the other type aren't used.
+	typ, err := Verify(TypeUnknown, []byte("MEUCIQD"), nil, []Key{{Type: TypeMinisign, Value: signer.publicKey()}})
+	if typ != TypeCosign || !errors.Is(err, ErrNoKeys) {
+		t.Errorf("Verify() = %q, %v, want cosign type and %v", typ, err, ErrNoKeys)
+	}
+}
@@ -0,0 +1,37 @@
+package signatures
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/crypto/openpgp" //nolint:staticcheck
+)
+
+func verifyGPG(signature []byte, data io.Reader, keys []Key) error {
+	var keyring openpgp.EntityList
+
+	for _, k := range keys {
+		entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(k.Value))
+		if err != nil {
+			return fmt.Errorf("error reading GPG public key: %w", err)
+		}
+
+		keyring = append(keyring, entities...)
+	}
+
+	var err error
+
+	if bytes.Contains(signature, []byte("BEGIN PGP SIGNATURE")) {
+		_, err = openpgp.CheckArmoredDetachedSignature(keyring, data, bytes.NewReader(signature))
+	} else {
+		_, err = openpgp.CheckDetachedSignature(keyring, data, bytes.NewReader(signature))
+	}
+
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSignature, err) //nolint:errorlint
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package signatures
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/openpgp"        //nolint:staticcheck
+	"golang.org/x/crypto/openpgp/armor"  //nolint:staticcheck
+	"golang.org/x/crypto/openpgp/packet" //nolint:staticcheck
+)
+
+// newGPGEntity returns GPG key pair and its armored public key.
+func newGPGEntity(t *testing.T, name string) (*openpgp.Entity, string) {
+	t.Helper()
+
+	// Small key is enough for tests and keeps them fast.
+	config := &packet.Config{RSABits: 1024} //nolint:exhaustivestruct
+
+	entity, err := openpgp.NewEntity(name, "", name+"@example.com", config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+
+	w, err := armor.Encode(&buf, openpgp.PublicKeyType, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := entity.Serialize(w); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return entity, buf.String()
+}
+
+func TestVerifyGPG(t *testing.T) {
+	t.Parallel()
+
+	const data = "release asset contents"
+
+	entity, publicKey := newGPGEntity(t, "signer")
+	_, otherKey := newGPGEntity(t, "other")
+
+	var binarySig, armoredSig bytes.Buffer
+
+	if err := openpgp.DetachSign(&binarySig, entity, strings.NewReader(data), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := openpgp.ArmoredDetachSign(&armoredSig, entity, strings.NewReader(data), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		signature []byte
+		data      string
+		keys      []string
+		wantErr   error
+	}{
+		{name: "valid binary", signature: binarySig.Bytes(), data: data, keys: []string{publicKey}},
+		{name: "valid armored", signature: armoredSig.Bytes(), data: data, keys: []string{publicKey}},
+		{name: "one of trusted keys", signature: binarySig.Bytes(), data: data, keys: []string{otherKey, publicKey}},
+		{
+			name:      "tampered payload",
+			signature: armoredSig.Bytes(),
+			data:      data + "!",
+			keys:      []string{publicKey},
+			wantErr:   ErrInvalidSignature,
+		},
+		{
+			name:      "wrong key",
+			signature: binarySig.Bytes(),
+			data:      data,
+			keys:      []string{otherKey},
+			wantErr:   ErrInvalidSignature,
+		},
+		{
+			name:      "malformed signature",
+			signature: []byte{0x88, 0x01, 0x02},
+			data:      data,
+			keys:      []string{publicKey},
+			wantErr:   ErrInvalidSignature,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			keys := make([]Key, 0, len(tt.keys))
+			for _, k := range tt.keys {
+				keys = append(keys, Key{Type: TypeGPG, Value: k})
+			}
+
+			_, err := Verify(TypeGPG, tt.signature, strings.NewReader(tt.data), keys)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Verify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerifyGPGInvalidKey(t *testing.T) {
+	t.Parallel()
+
+	_, err := Verify(TypeGPG, []byte{0x88}, strings.NewReader("data"), []Key{{Type: TypeGPG, Value: "not a key"}})
+	if err == nil || errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("Verify() error = %v, want key parsing error", err)
+	}
+}
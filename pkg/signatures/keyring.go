@@ -0,0 +1,118 @@
+package signatures
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	TypeUnknown  = Type("")
+	TypeMinisign = Type("minisign")
+	TypeCosign   = Type("cosign")
+	TypeGPG      = Type("gpg")
+)
+
+type Type string
+
+// Key is a public key that is trusted to sign releases.
+type Key struct {
+	Type Type `json:"type"`
+	// Value is a public key in the format used by the signing tool:
+	// minisign public key, PEM-encoded cosign public key or armored GPG public key.
+	Value string `json:"value"`
+}
+
+// Keyring stores trusted keys per repo owner.
+type Keyring struct {
+	Owners map[string][]Key `json:"owners"`
+}
+
+// LoadKeyring reads keyring from file. It returns empty keyring if file doesn't exist yet.
+func LoadKeyring(src string) (Keyring, error) {
+	b, err := os.ReadFile(src)
+	if errors.Is(err, os.ErrNotExist) {
+		return Keyring{Owners: map[string][]Key{}}, nil
+	} else if err != nil {
+		return Keyring{}, fmt.Errorf("error reading keyring file: %w", err)
+	}
+
+	var k Keyring
+	if err := json.Unmarshal(b, &k); err != nil {
+		return Keyring{}, fmt.Errorf("error unmarshaling keyring: %w", err)
+	}
+
+	if k.Owners == nil {
+		k.Owners = map[string][]Key{}
+	}
+
+	return k, nil
+}
+
+func SaveKeyring(dest string, k Keyring, permissions os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(dest), permissions); err != nil {
+		return fmt.Errorf("error creating folder for keyring: %w", err)
+	}
+
+	b, err := json.MarshalIndent(&k, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling keyring: %w", err)
+	}
+
+	if err := os.WriteFile(dest, b, permissions); err != nil {
+		return fmt.Errorf("error writing keyring file '%s': %w", dest, err)
+	}
+
+	return nil
+}
+
+// Keys returns keys trusted for the owner. Owner names are case-insensitive.
+func (k Keyring) Keys(owner string) []Key {
+	return k.Owners[strings.ToLower(owner)]
+}
+
+// Trust adds key to the list of keys trusted for the owner.
+func (k *Keyring) Trust(owner string, key Key) {
+	owner = strings.ToLower(owner)
+
+	for _, existing := range k.Owners[owner] {
+		if existing == key {
+			return
+		}
+	}
+
+	k.Owners[owner] = append(k.Owners[owner], key)
+}
+
+// Untrust removes all keys trusted for the owner and returns the number of removed keys.
+func (k *Keyring) Untrust(owner string) int {
+	owner = strings.ToLower(owner)
+	removed := len(k.Owners[owner])
+
+	delete(k.Owners, owner)
+
+	return removed
+}
+
+// DetectKeyType detects type of the public key using its contents.
+func DetectKeyType(key string) Type {
+	switch {
+	case strings.Contains(key, "BEGIN PGP PUBLIC KEY BLOCK"):
+		return TypeGPG
+	case strings.Contains(key, "BEGIN PUBLIC KEY"):
+		return TypeCosign
+	case strings.HasPrefix(strings.TrimSpace(lastLine(key)), "RW"):
+		return TypeMinisign
+	}
+
+	return TypeUnknown
+}
+
+func lastLine(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+
+	return lines[len(lines)-1]
+}
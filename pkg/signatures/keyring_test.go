@@ -0,0 +1,125 @@
+package signatures
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestKeyringLoadSave(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "trust", "keyring.json")
+
+	k, err := LoadKeyring(path)
+	if err != nil {
+		t.Fatalf("LoadKeyring() error = %v", err)
+	}
+
+	if len(k.Owners) != 0 {
+		t.Fatalf("LoadKeyring() = %+v, want empty keyring", k)
+	}
+
+	minisignKey := Key{Type: TypeMinisign, Value: "RWQ..."}
+	cosignKey := Key{Type: TypeCosign, Value: "-----BEGIN PUBLIC KEY-----"}
+
+	k.Trust("Derailed", minisignKey)
+	k.Trust("derailed", minisignKey)
+	k.Trust("derailed", cosignKey)
+	k.Trust("sigstore", cosignKey)
+
+	if err := SaveKeyring(path, k, 0o700); err != nil {
+		t.Fatalf("SaveKeyring() error = %v", err)
+	}
+
+	loaded, err := LoadKeyring(path)
+	if err != nil {
+		t.Fatalf("LoadKeyring() error = %v", err)
+	}
+
+	if want := []Key{minisignKey, cosignKey}; !reflect.DeepEqual(loaded.Keys("DERAILED"), want) {
+		t.Errorf("Keys() = %+v, want %+v", loaded.Keys("DERAILED"), want)
+	}
+
+	if removed := loaded.Untrust("Derailed"); removed != 2 {
+		t.Errorf("Untrust() = %d, want 2", removed)
+	}
+
+	if keys := loaded.Keys("derailed"); len(keys) != 0 {
+		t.Errorf("Keys() after Untrust() = %+v, want none", keys)
+	}
+
+	if keys := loaded.Keys("sigstore"); len(keys) != 1 {
+		t.Errorf("Keys() of other owner = %+v, want 1 key", keys)
+	}
+}
+
+func TestLoadKeyringInvalid(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	invalid := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(invalid, []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadKeyring(invalid); err == nil {
+		t.Errorf("LoadKeyring() error = nil, want error")
+	}
+
+	// Keyring without owners can still be modified.
+	empty := filepath.Join(dir, "empty.json")
+	if err := os.WriteFile(empty, []byte("{}"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	k, err := LoadKeyring(empty)
+	if err != nil {
+		t.Fatalf("LoadKeyring() error = %v", err)
+	}
+
+	k.Trust("owner", Key{Type: TypeGPG, Value: "key"})
+}
+
+func TestDetectKeyType(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		key  string
+		want Type
+	}{
+		{
+			name: "gpg",
+			key:  "-----BEGIN PGP PUBLIC KEY BLOCK-----\n\nmQINBF...\n-----END PGP PUBLIC KEY BLOCK-----\n",
+			want: TypeGPG,
+		},
+		{
+			name: "cosign",
+			key:  "-----BEGIN PUBLIC KEY-----\nMFkwEwYH...\n-----END PUBLIC KEY-----\n",
+			want: TypeCosign,
+		},
+		{name: "minisign", key: "RWQf6LRCGA9i53mlYecO4IzT51TGPpvWucNSCh1CBM0QTaLn73Y7GFO3", want: TypeMinisign},
+		{
+			name: "minisign with comment",
+			key:  "untrusted comment: minisign public key 37623B4C\nRWQf6LRCGA9i53mlYecO4IzT51TGPpvWucNSCh1CBM0QTaLn73Y7GFO3\n",
+			want: TypeMinisign,
+		},
+		{name: "unknown", key: "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5", want: TypeUnknown},
+		{name: "empty", key: "", want: TypeUnknown},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := DetectKeyType(tt.key); got != tt.want {
+				t.Errorf("DetectKeyType() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
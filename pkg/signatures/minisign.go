@@ -0,0 +1,151 @@
+package signatures
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	minisignAlgSize    = 2
+	minisignKeyIDSize  = 8
+	minisignHeaderSize = minisignAlgSize + minisignKeyIDSize
+	trustedCommentTag  = "trusted comment: "
+)
+
+//nolint:gochecknoglobals
+var (
+	// minisignLegacyAlg signs data directly, minisignHashedAlg signs BLAKE2b-512 digest of data.
+	minisignLegacyAlg = []byte("Ed")
+	minisignHashedAlg = []byte("ED")
+)
+
+type minisignKey struct {
+	id  []byte
+	key ed25519.PublicKey
+}
+
+type minisignSignature struct {
+	alg             []byte
+	keyID           []byte
+	signature       []byte
+	trustedComment  string
+	globalSignature []byte
+}
+
+func verifyMinisign(signature []byte, data io.Reader, keys []Key) error {
+	sig, err := parseMinisignSignature(string(signature))
+	if err != nil {
+		return err
+	}
+
+	message, err := minisignMessage(sig.alg, data)
+	if err != nil {
+		return err
+	}
+
+	for _, k := range keys {
+		key, err := parseMinisignKey(k.Value)
+		if err != nil {
+			return err
+		}
+
+		if !bytes.Equal(key.id, sig.keyID) {
+			continue
+		}
+
+		if !ed25519.Verify(key.key, message, sig.signature) {
+			return fmt.Errorf("%w: minisign signature doesn't match", ErrInvalidSignature)
+		}
+
+		// Global signature protects trusted comment from tampering.
+		global := append(append([]byte{}, sig.signature...), sig.trustedComment...)
+		if !ed25519.Verify(key.key, global, sig.globalSignature) {
+			return fmt.Errorf("%w: minisign trusted comment doesn't match", ErrInvalidSignature)
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("%w: signature was created with untrusted minisign key", ErrInvalidSignature)
+}
+
+func minisignMessage(alg []byte, data io.Reader) ([]byte, error) {
+	if bytes.Equal(alg, minisignLegacyAlg) {
+		message, err := io.ReadAll(data)
+		if err != nil {
+			return nil, fmt.Errorf("error reading signed data: %w", err)
+		}
+
+		return message, nil
+	}
+
+	h, err := blake2b.New512(nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating hash: %w", err)
+	}
+
+	if _, err := io.Copy(h, data); err != nil {
+		return nil, fmt.Errorf("error hashing signed data: %w", err)
+	}
+
+	return h.Sum(nil), nil
+}
+
+// parseMinisignKey parses public key with or without "untrusted comment" line.
+func parseMinisignKey(s string) (minisignKey, error) {
+	b, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lastLine(s)))
+	if err != nil {
+		return minisignKey{}, fmt.Errorf("error decoding minisign public key: %w", err)
+	}
+
+	if len(b) != minisignHeaderSize+ed25519.PublicKeySize || !bytes.Equal(b[:minisignAlgSize], minisignLegacyAlg) {
+		return minisignKey{}, fmt.Errorf("invalid minisign public key")
+	}
+
+	return minisignKey{
+		id:  b[minisignAlgSize:minisignHeaderSize],
+		key: b[minisignHeaderSize:],
+	}, nil
+}
+
+func parseMinisignSignature(s string) (minisignSignature, error) {
+	const linesCount = 4
+
+	lines := strings.Split(strings.TrimSpace(strings.ReplaceAll(s, "\r\n", "\n")), "\n")
+	if len(lines) < linesCount || !strings.HasPrefix(lines[2], trustedCommentTag) {
+		return minisignSignature{}, fmt.Errorf("invalid minisign signature format")
+	}
+
+	b, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[1]))
+	if err != nil {
+		return minisignSignature{}, fmt.Errorf("error decoding minisign signature: %w", err)
+	}
+
+	if len(b) != minisignHeaderSize+ed25519.SignatureSize {
+		return minisignSignature{}, fmt.Errorf("invalid minisign signature size")
+	}
+
+	alg := b[:minisignAlgSize]
+	if !bytes.Equal(alg, minisignLegacyAlg) && !bytes.Equal(alg, minisignHashedAlg) {
+		return minisignSignature{}, fmt.Errorf("unsupported minisign signature algorithm '%s'", alg)
+	}
+
+	global, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[3]))
+	if err != nil {
+		return minisignSignature{}, fmt.Errorf("error decoding minisign global signature: %w", err)
+	}
+
+	return minisignSignature{
+		alg:             alg,
+		keyID:           b[minisignAlgSize:minisignHeaderSize],
+		signature:       b[minisignHeaderSize:],
+		trustedComment:  strings.TrimPrefix(lines[2], trustedCommentTag),
+		globalSignature: global,
+	}, nil
+}
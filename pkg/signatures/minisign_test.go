@@ -0,0 +1,208 @@
+package signatures
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// minisignSigner creates minisign keys and signatures from a fixed seed, so signatures are the same in each run.
+type minisignSigner struct {
+	id  []byte
+	key ed25519.PrivateKey
+}
+
+func newMinisignSigner(seed byte, id string) minisignSigner {
+	return minisignSigner{
+		id:  []byte(id),
+		key: ed25519.NewKeyFromSeed(bytes.Repeat([]byte{seed}, ed25519.SeedSize)),
+	}
+}
+
+func (s minisignSigner) publicKey() string {
+	b := append(append(append([]byte{}, minisignLegacyAlg...), s.id...), s.key.Public().(ed25519.PublicKey)...)
+
+	return "untrusted comment: minisign public key\n" + base64.StdEncoding.EncodeToString(b) + "\n"
+}
+
+func (s minisignSigner) sign(alg []byte, data string, trustedComment string) string {
+	message := []byte(data)
+	if bytes.Equal(alg, minisignHashedAlg) {
+		digest := blake2b.Sum512(message)
+		message = digest[:]
+	}
+
+	sig := ed25519.Sign(s.key, message)
+	global := ed25519.Sign(s.key, append(append([]byte{}, sig...), trustedComment...))
+	blob := append(append(append([]byte{}, alg...), s.id...), sig...)
+
+	return fmt.Sprintf("untrusted comment: signature from minisign secret key\n%s\ntrusted comment: %s\n%s\n",
+		base64.StdEncoding.EncodeToString(blob), trustedComment, base64.StdEncoding.EncodeToString(global))
+}
+
+func TestVerifyMinisign(t *testing.T) {
+	t.Parallel()
+
+	const data = "release asset contents"
+
+	signer := newMinisignSigner(1, "12345678")
+	other := newMinisignSigner(2, "87654321")
+	// sameID is a different key that has the same key ID as signer.
+	sameID := newMinisignSigner(3, "12345678")
+
+	valid := signer.sign(minisignHashedAlg, data, "timestamp:1634567890")
+	lines := strings.Split(valid, "\n")
+
+	tests := []struct {
+		name      string
+		signature string
+		data      string
+		keys      []string
+		wantErr   error
+	}{
+		{name: "valid prehashed", signature: valid, data: data, keys: []string{signer.publicKey()}},
+		{
+			name:      "valid legacy",
+			signature: signer.sign(minisignLegacyAlg, data, "timestamp:1634567890"),
+			data:      data,
+			keys:      []string{signer.publicKey()},
+		},
+		{name: "key without comment", signature: valid, data: data, keys: []string{lastLine(signer.publicKey())}},
+		{name: "one of trusted keys", signature: valid, data: data, keys: []string{other.publicKey(), signer.publicKey()}},
+		{name: "tampered payload", signature: valid, data: data + "!", keys: []string{signer.publicKey()},
+			wantErr: ErrInvalidSignature},
+		{name: "wrong key", signature: valid, data: data, keys: []string{other.publicKey()}, wantErr: ErrInvalidSignature},
+		{
+			name:      "wrong key with the same id",
+			signature: valid,
+			data:      data,
+			keys:      []string{sameID.publicKey()},
+			wantErr:   ErrInvalidSignature,
+		},
+		{
+			name:      "tampered trusted comment",
+			signature: strings.Join([]string{lines[0], lines[1], "trusted comment: timestamp:0", lines[3]}, "\n"),
+			data:      data,
+			keys:      []string{signer.publicKey()},
+			wantErr:   ErrInvalidSignature,
+		},
+		{
+			name: "bad global signature",
+			signature: strings.Join([]string{lines[0], lines[1], lines[2],
+				base64.StdEncoding.EncodeToString(make([]byte, ed25519.SignatureSize))}, "\n"),
+			data:    data,
+			keys:    []string{signer.publicKey()},
+			wantErr: ErrInvalidSignature,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			keys := make([]Key, 0, len(tt.keys))
+			for _, k := range tt.keys {
+				keys = append(keys, Key{Type: TypeMinisign, Value: k})
+			}
+
+			_, err := Verify(TypeMinisign, []byte(tt.signature), strings.NewReader(tt.data), keys)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Verify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseMinisignSignature(t *testing.T) {
+	t.Parallel()
+
+	signer := newMinisignSigner(1, "12345678")
+	lines := strings.Split(signer.sign(minisignHashedAlg, "data", "comment"), "\n")
+
+	tests := []struct {
+		name      string
+		signature string
+		wantErr   bool
+	}{
+		{name: "valid", signature: strings.Join(lines, "\n")},
+		{name: "crlf", signature: strings.Join(lines, "\r\n")},
+		{name: "missing global signature", signature: strings.Join(lines[:3], "\n"), wantErr: true},
+		{
+			name:      "missing trusted comment",
+			signature: strings.Join([]string{lines[0], lines[1], "comment", lines[3]}, "\n"),
+			wantErr:   true,
+		},
+		{
+			name:      "invalid base64",
+			signature: strings.Join([]string{lines[0], "!!!", lines[2], lines[3]}, "\n"),
+			wantErr:   true,
+		},
+		{
+			name:      "short signature",
+			signature: strings.Join([]string{lines[0], "RURhYmNk", lines[2], lines[3]}, "\n"),
+			wantErr:   true,
+		},
+		{
+			name: "unsupported algorithm",
+			signature: strings.Join([]string{lines[0],
+				base64.StdEncoding.EncodeToString(append([]byte("XX12345678"), make([]byte, ed25519.SignatureSize)...)),
+				lines[2], lines[3]}, "\n"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := parseMinisignSignature(tt.signature)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseMinisignSignature() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseMinisignKey(t *testing.T) {
+	t.Parallel()
+
+	signer := newMinisignSigner(1, "12345678")
+
+	tests := []struct {
+		name    string
+		key     string
+		wantErr bool
+	}{
+		{name: "valid", key: signer.publicKey()},
+		{name: "invalid base64", key: "RW!!!", wantErr: true},
+		{name: "short key", key: "RWRhYmNk", wantErr: true},
+		{
+			name:    "unsupported algorithm",
+			key:     base64.StdEncoding.EncodeToString(append([]byte("ED12345678"), make([]byte, ed25519.PublicKeySize)...)),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := parseMinisignKey(tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseMinisignKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package signatures
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrNoKeys is returned when no trusted keys of the signature type are available.
+var ErrNoKeys = errors.New("no trusted keys")
+
+// ErrInvalidSignature is returned when signature wasn't created with any of the trusted keys.
+var ErrInvalidSignature = errors.New("invalid signature")
+
+// Verify verifies signature of the data using trusted keys of the same type as the signature.
+// Signature type is detected using signature contents if it's unknown.
+func Verify(t Type, signature []byte, data io.Reader, keys []Key) (Type, error) {
+	if t == TypeUnknown {
+		t = detectSignatureType(signature)
+	}
+
+	var matching []Key
+
+	for _, key := range keys {
+		if key.Type == t {
+			matching = append(matching, key)
+		}
+	}
+
+	if len(matching) == 0 {
+		return t, fmt.Errorf("%w of type '%s'", ErrNoKeys, t)
+	}
+
+	var err error
+
+	switch t {
+	case TypeMinisign:
+		err = verifyMinisign(signature, data, matching)
+	case TypeCosign:
+		err = verifyCosign(signature, data, matching)
+	case TypeGPG:
+		err = verifyGPG(signature, data, matching)
+	case TypeUnknown:
+		err = fmt.Errorf("unknown signature type")
+	}
+
+	return t, err
+}